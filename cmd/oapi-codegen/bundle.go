@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// overlayOptions returns the options loading the spec with the Overlay of
+// opts, which warn about the constructs of Swagger 2.0 specs which don't
+// translate losslessly.
+func overlayOptions(opts configuration) util.LoadSwaggerWithOverlayOpts {
+	overlayOpts := util.LoadSwaggerWithOverlayOpts{
+		Path: opts.OutputOptions.Overlay.Path,
+		// default to strict, but can be overridden
+		Strict: true,
+		Warn: func(warning util.Swagger2Warning) {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+		},
+	}
+
+	if opts.OutputOptions.Overlay.Strict != nil {
+		overlayOpts.Strict = *opts.OutputOptions.Overlay.Strict
+	}
+	return overlayOpts
+}
+
+// runBundle runs the bundle command with args, writing the spec which code
+// is generated from, once the Overlay is applied, its operations filtered
+// and its unused components pruned, as a single self-contained document.
+func runBundle(args []string) error {
+	flags := flag.NewFlagSet("bundle", flag.ExitOnError)
+	configFile := flags.String("config", "", "A YAML config file whose output-options filter, prune and overlay the spec, as when generating code.")
+	outputFile := flags.String("o", "", "Where to output the bundled spec, stdout is default.")
+	format := flags.String("format", "", `The format of the bundled spec, "json" or "yaml"; defaults to the output file's extension, or YAML.`)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen bundle [flags] spec\n\nWrites the spec which code is generated from as a single self-contained document.\n\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("exactly one spec must be given")
+	}
+	if *format == "" {
+		*format = "yaml"
+		if strings.EqualFold(filepath.Ext(*outputFile), ".json") {
+			*format = "json"
+		}
+	}
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf(`unknown format %q, which must be "json" or "yaml"`, *format)
+	}
+
+	var opts configuration
+	if *configFile != "" {
+		buf, err := os.ReadFile(*configFile)
+		if err != nil {
+			return fmt.Errorf("error reading config file '%s': %w", *configFile, err)
+		}
+		if err := yaml.Unmarshal(buf, &opts); err != nil {
+			return fmt.Errorf("error parsing '%s' as YAML: %w", *configFile, err)
+		}
+	}
+	opts.Configuration = opts.UpdateDefaults()
+
+	spec, err := util.LoadSwaggerWithOverlay(flags.Arg(0), overlayOptions(opts))
+	if err != nil {
+		return fmt.Errorf("error loading swagger spec in %s: %w", flags.Arg(0), err)
+	}
+	codegen.BundleSpec(spec, opts.Configuration)
+
+	if *outputFile == "" {
+		return writeSpec(os.Stdout, spec, *format)
+	}
+	if err := os.MkdirAll(filepath.Dir(*outputFile), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := writeSpec(&buf, spec, *format); err != nil {
+		return err
+	}
+	return os.WriteFile(*outputFile, buf.Bytes(), 0o644)
+}
+
+// writeSpec writes spec to w in format, "json" or "yaml".
+func writeSpec(w io.Writer, spec *openapi3.T, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(spec); err != nil {
+			return fmt.Errorf("error marshaling spec as JSON: %w", err)
+		}
+		return nil
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return fmt.Errorf("error marshaling spec as YAML: %w", err)
+	}
+	return enc.Close()
+}
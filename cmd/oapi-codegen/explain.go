@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// writeSourceMap writes sourceMap to path as JSON.
+func writeSourceMap(path string, sourceMap *codegen.SourceMap) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, sourceMap); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// readSourceMap reads the source map written to path.
+func readSourceMap(path string) (*codegen.SourceMap, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source map '%s': %w", path, err)
+	}
+	var sourceMap codegen.SourceMap
+	if err := json.Unmarshal(buf, &sourceMap); err != nil {
+		return nil, fmt.Errorf("error parsing source map '%s': %w", path, err)
+	}
+	return &sourceMap, nil
+}
+
+// runExplain runs the explain command with args, writing where the Go
+// identifier it's given is generated from, and how it's named, to w.
+func runExplain(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	configFile := flags.String("config", "", "A YAML config file which code is generated with from the spec.")
+	sourceMapFile := flags.String("source-map", "", "A source map written when generating code, which the identifier is looked up in rather than in code generated from a spec.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen explain [flags] spec identifier\n       oapi-codegen explain -source-map file identifier\n\nExplains which part of the spec a Go identifier of the generated code is generated from, and how it's named.\n\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	var sourceMap *codegen.SourceMap
+	if *sourceMapFile != "" {
+		if flags.NArg() != 1 {
+			flags.Usage()
+			return fmt.Errorf("exactly one identifier must be given")
+		}
+		var err error
+		if sourceMap, err = readSourceMap(*sourceMapFile); err != nil {
+			return err
+		}
+	} else {
+		if flags.NArg() != 2 {
+			flags.Usage()
+			return fmt.Errorf("exactly one spec and one identifier must be given")
+		}
+		opts, err := readConfiguration(*configFile)
+		if err != nil {
+			return err
+		}
+		spec, err := util.LoadSwaggerWithOverlay(flags.Arg(0), overlayOptions(opts))
+		if err != nil {
+			return fmt.Errorf("error loading swagger spec in %s: %w", flags.Arg(0), err)
+		}
+		if *configFile == "" && util.IsModelsOnlySpec(spec) {
+			opts.Generate = codegen.GenerateOptions{Models: true}
+		}
+		result, err := codegen.GenerateWithSourceMap(spec, opts.Configuration)
+		if err != nil {
+			return fmt.Errorf("error generating code: %w", err)
+		}
+		sourceMap = result.SourceMap
+	}
+
+	identifier := flags.Arg(flags.NArg() - 1)
+	entries := sourceMap.Lookup(identifier)
+	if len(entries) == 0 {
+		return fmt.Errorf("%s isn't generated from the spec", identifier)
+	}
+	for i, e := range entries {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "%s (%s)\n", e.Identifier, e.Kind)
+		_, _ = fmt.Fprintf(w, "  from: %s\n", e.Pointer)
+		if e.File != "" {
+			_, _ = fmt.Fprintf(w, "  at:   %s:%d:%d\n", e.File, e.Line, e.Column)
+		}
+		_, _ = fmt.Fprintf(w, "  rule: %s\n", e.Rule)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// codeSwagger2Conversion is a construct of a Swagger 2.0 spec which doesn't
+// translate losslessly into OpenAPI 3.0.
+const codeSwagger2Conversion = "swagger2-conversion"
+
+// lintRules describe the codes of the diagnostics, as the rules of SARIF
+// logs.
+var lintRules = map[string]string{
+	codegen.CodeInvalidValue:             "A value of the spec can't be copied into the generated code.",
+	codegen.CodeTypeNameCollision:        "The names of generated types collide.",
+	codegen.CodeDefaultOperationID:       "An operation has no operationId, so its name is derived from its method and path.",
+	codegen.CodeIgnoredPatternProperties: "The patternProperties of a schema are ignored.",
+	codeSwagger2Conversion:               "A construct of a Swagger 2.0 spec doesn't translate losslessly into OpenAPI 3.0.",
+}
+
+// swagger2Diagnostic returns the diagnostic of the warning about a construct
+// of a Swagger 2.0 spec.
+func swagger2Diagnostic(warning util.Swagger2Warning) codegen.Diagnostic {
+	return codegen.Diagnostic{
+		Severity: codegen.SeverityWarning,
+		Code:     codeSwagger2Conversion,
+		Message:  warning.Message,
+		File:     warning.File,
+		Line:     warning.Line,
+		Column:   warning.Column,
+	}
+}
+
+// readConfiguration reads the configuration of the commands which inspect
+// the code generated from the spec, from configFile, or, without it, the
+// configuration of the code generated by default.
+func readConfiguration(configFile string) (configuration, error) {
+	opts := configuration{
+		Configuration: codegen.Configuration{
+			Generate: codegen.GenerateOptions{
+				EchoServer:   true,
+				Client:       true,
+				Models:       true,
+				EmbeddedSpec: true,
+			},
+		},
+	}
+	if configFile != "" {
+		opts = configuration{}
+		buf, err := os.ReadFile(configFile)
+		if err != nil {
+			return opts, fmt.Errorf("error reading config file '%s': %w", configFile, err)
+		}
+		if err := yaml.Unmarshal(buf, &opts); err != nil {
+			return opts, fmt.Errorf("error parsing '%s' as YAML: %w", configFile, err)
+		}
+	}
+	if opts.PackageName == "" {
+		opts.PackageName = "api"
+	}
+	opts.Configuration = opts.UpdateDefaults()
+	return opts, nil
+}
+
+// runLint runs the lint command with args, writing the diagnostics of the
+// spec to w. It reports whether any of them is an error.
+func runLint(args []string, w io.Writer) (bool, error) {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	configFile := flags.String("config", "", "A YAML config file whose options the spec is linted for, as when generating code.")
+	format := flags.String("format", "text", `The format of the diagnostics, "text", "json" or "sarif".`)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen lint [flags] spec\n\nReports the problems of the spec in generating code from it, with their locations in the spec.\n\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return false, fmt.Errorf("exactly one spec must be given")
+	}
+	if *format != "text" && *format != "json" && *format != "sarif" {
+		return false, fmt.Errorf(`unknown format %q, which must be "text", "json" or "sarif"`, *format)
+	}
+
+	opts, err := readConfiguration(*configFile)
+	if err != nil {
+		return false, err
+	}
+
+	var diagnostics []codegen.Diagnostic
+	overlayOpts := overlayOptions(opts)
+	overlayOpts.Warn = func(warning util.Swagger2Warning) {
+		diagnostics = append(diagnostics, swagger2Diagnostic(warning))
+	}
+	spec, err := util.LoadSwaggerWithOverlay(flags.Arg(0), overlayOpts)
+	if err != nil {
+		return false, fmt.Errorf("error loading swagger spec in %s: %w", flags.Arg(0), err)
+	}
+	if *configFile == "" && util.IsModelsOnlySpec(spec) {
+		opts.Generate = codegen.GenerateOptions{Models: true}
+	}
+
+	linted, err := codegen.Lint(spec, opts.Configuration)
+	if err != nil {
+		return false, err
+	}
+	diagnostics = append(diagnostics, linted...)
+
+	failed := false
+	for _, d := range diagnostics {
+		if d.Severity == codegen.SeverityError {
+			failed = true
+		}
+	}
+
+	switch *format {
+	case "json":
+		if diagnostics == nil {
+			diagnostics = []codegen.Diagnostic{}
+		}
+		err = writeJSON(w, diagnostics)
+	case "sarif":
+		err = writeJSON(w, newSARIFLog(diagnostics))
+	default:
+		for _, d := range diagnostics {
+			if _, err = fmt.Fprintln(w, d); err != nil {
+				break
+			}
+		}
+	}
+	return failed, err
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// The parts of SARIF 2.1.0 logs which diagnostics are reported with.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// newSARIFLog returns the SARIF log reporting diagnostics, with the rules of
+// their codes.
+func newSARIFLog(diagnostics []codegen.Diagnostic) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "oapi-codegen",
+			InformationURI: "https://github.com/oapi-codegen/oapi-codegen",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	codes := map[string]bool{}
+	for _, d := range diagnostics {
+		result := sarifResult{
+			RuleID:  d.Code,
+			Level:   string(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+		if d.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(d.File)},
+			}}
+			if d.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+		codes[d.Code] = true
+	}
+
+	for code := range codes {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               code,
+			ShortDescription: sarifMessage{Text: lintRules[code]},
+		})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+// sarifURI returns the URI of the file of a diagnostic, relative to the
+// working directory when it's a local file within it.
+func sarifURI(file string) string {
+	if u, err := url.Parse(file); err == nil && u.Scheme != "" && u.Host != "" {
+		return file
+	}
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(wd, file); err == nil && filepath.IsLocal(rel) {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
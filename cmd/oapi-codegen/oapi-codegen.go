@@ -15,15 +15,22 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pmezard/go-difflib/difflib"
 	"go.yaml.in/yaml/v3"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
@@ -48,6 +55,12 @@ var (
 	flagPrintUsage     bool
 	flagGenerate       string
 	flagTemplatesDir   string
+	flagCheck          bool
+	flagStale          bool
+	flagRegenerate     bool
+	flagWatch          bool
+
+	flagDiagnosticsFormat string
 
 	// Deprecated: The options below will be removed in a future
 	// release. Please use the new config file format.
@@ -66,6 +79,11 @@ type configuration struct {
 
 	// OutputFile is the filename to output.
 	OutputFile string `yaml:"output,omitempty"`
+
+	// SourceMapFile is the filename to output the source map of the
+	// generated code to, which maps its identifiers to the parts of the spec
+	// they're generated from.
+	SourceMapFile string `yaml:"source-map,omitempty"`
 }
 
 // oldConfiguration is deprecated. Please add no more flags here. It is here
@@ -90,6 +108,29 @@ type oldConfiguration struct {
 var noVCSVersionOverride string
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "bundle" {
+		if err := runBundle(os.Args[2:]); err != nil {
+			errExit("error bundling spec: %s\n", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		if err := runExplain(os.Args[2:], os.Stdout); err != nil {
+			errExit("error explaining identifier: %s\n", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		failed, err := runLint(os.Args[2:], os.Stdout)
+		if err != nil {
+			errExit("error linting spec: %s\n", err)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.StringVar(&flagOutputFile, "o", "", "Where to output generated code, stdout is default.")
 	flag.BoolVar(&flagOldConfigStyle, "old-config-style", false, "Whether to use the older style config file format.")
 	flag.BoolVar(&flagOutputConfig, "output-config", false, "When true, outputs a configuration file for oapi-codegen using current settings.")
@@ -98,6 +139,11 @@ func main() {
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 	flag.BoolVar(&flagPrintUsage, "h", false, "Same as -help.")
+	flag.BoolVar(&flagCheck, "check", false, "Check that the output file is up to date, printing a diff and exiting non-zero if it isn't, without writing it.")
+	flag.BoolVar(&flagStale, "stale", false, "Walk the directory given as argument, the current directory by default, for generated files recording a fingerprint, listing the ones whose inputs changed and exiting non-zero if there are any.")
+	flag.BoolVar(&flagRegenerate, "regenerate", false, "Like -stale, but regenerate the stale files instead of listing them.")
+	flag.BoolVar(&flagWatch, "watch", false, "Keep on running, regenerating the output whenever the spec, the documents of its external references, the Overlay, the config file or the user templates change.")
+	flag.StringVar(&flagDiagnosticsFormat, "diagnostics-format", "text", `The format of the warnings and errors of generation on stderr, "text" or "json", an array of diagnostics with their locations in the spec.`)
 
 	// All flags below are deprecated, and will be removed in a future release. Please do not
 	// update their behavior.
@@ -120,6 +166,10 @@ func main() {
 		os.Exit(0)
 	}
 
+	if flagDiagnosticsFormat != "text" && flagDiagnosticsFormat != "json" {
+		errExit("unknown diagnostics format %q, which must be \"text\" or \"json\"\n", flagDiagnosticsFormat)
+	}
+
 	if flagPrintVersion {
 		bi, ok := debug.ReadBuildInfo()
 		if !ok {
@@ -135,12 +185,40 @@ func main() {
 		return
 	}
 
+	if flagStale || flagRegenerate {
+		if flag.NArg() > 1 {
+			errExit("Only one directory is accepted with -stale and -regenerate\n")
+		}
+		root := "."
+		if flag.NArg() == 1 {
+			root = flag.Arg(0)
+		}
+		stale, err := checkStale(os.Stdout, root, flagRegenerate)
+		if err != nil {
+			errExit("error checking for stale generated files: %s\n", err)
+		}
+		if stale > 0 && !flagRegenerate {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if flag.NArg() < 1 {
 		errExit("Please specify a path to a OpenAPI 3.0 spec file\n")
 	} else if flag.NArg() > 1 {
 		errExit("Only one OpenAPI 3.0 spec file is accepted and it must be the last CLI argument\n")
 	}
 
+	if flagWatch {
+		if flagOutputConfig || flagCheck {
+			errExit("-watch can't be used with -output-config or -check\n")
+		}
+		if err := runWatch(os.Args[1:], flagConfigFile, flag.Arg(0)); err != nil {
+			errExit("error watching spec: %s\n", err)
+		}
+		return
+	}
+
 	// We will try to infer whether the user has an old-style config, or a new
 	// style. Start with the command line argument. If it's true, we know it's
 	// old config style.
@@ -209,6 +287,10 @@ func main() {
 	}
 
 	var opts configuration
+	// defaultTargets is whether the code to generate is the default, rather
+	// than configured, which is only models for JSON Schema and AsyncAPI
+	// documents.
+	defaultTargets := false
 	if !*oldConfigStyle {
 		// We simply read the configuration from disk.
 		if flagConfigFile != "" {
@@ -235,6 +317,7 @@ func main() {
 				},
 				OutputFile: flagOutputFile,
 			}
+			defaultTargets = flagGenerate == "types,client,server,spec"
 		}
 
 		if err := updateConfigFromFlags(&opts); err != nil {
@@ -265,6 +348,12 @@ func main() {
 	// fields.
 	opts.Configuration = opts.UpdateDefaults()
 
+	// The fingerprint records the configuration, so the package can't be
+	// detected from the output directory, which may change in between.
+	if opts.OutputOptions.Fingerprint && opts.PackageName == "" {
+		errExit("output-options.fingerprint requires the configuration's package\n")
+	}
+
 	if err := detectPackageName(&opts); err != nil {
 		errExit("%s\n", err)
 	}
@@ -274,25 +363,10 @@ func main() {
 		errExit("configuration error: %v\n", err)
 	}
 
-	if warnings := opts.Generate.Warnings(); len(warnings) > 0 {
-		var out strings.Builder
-		out.WriteString("WARNING: A number of warning(s) were returned when validating the GenerateOptions:")
-		for k, v := range warnings {
-			out.WriteString("\n- " + k + ": " + v)
-		}
-
-		_, _ = fmt.Fprint(os.Stderr, out.String())
-	}
-
-	if warnings := opts.Warnings(); len(warnings) > 0 {
-		var out strings.Builder
-		out.WriteString("WARNING: A number of cross-field configuration warning(s) were returned:")
-		for k, v := range warnings {
-			out.WriteString("\n- " + k + ": " + v)
-		}
-		out.WriteString("\n")
-
-		_, _ = fmt.Fprint(os.Stderr, out.String())
+	// With the JSON format, the warnings are diagnostics of the generation.
+	if flagDiagnosticsFormat == "text" {
+		writeWarnings(os.Stderr, "A number of warning(s) were returned when validating the GenerateOptions", opts.Generate.Warnings())
+		writeWarnings(os.Stderr, "A number of cross-field configuration warning(s) were returned", opts.Warnings())
 	}
 
 	// If the user asked to output configuration, output it to stdout and exit
@@ -308,26 +382,83 @@ func main() {
 		return
 	}
 
-	overlayOpts := util.LoadSwaggerWithOverlayOpts{
-		Path: opts.OutputOptions.Overlay.Path,
-		// default to strict, but can be overridden
-		Strict: true,
+	if flagCheck && opts.OutputFile == "" {
+		errExit("-check requires an output file, set with -o or the configuration's output\n")
 	}
 
-	if opts.OutputOptions.Overlay.Strict != nil {
-		overlayOpts.Strict = *opts.OutputOptions.Overlay.Strict
+	overlayOpts := overlayOptions(opts)
+	var diagnostics []codegen.Diagnostic
+	if flagDiagnosticsFormat == "json" {
+		overlayOpts.Warn = func(warning util.Swagger2Warning) {
+			diagnostics = append(diagnostics, swagger2Diagnostic(warning))
+		}
 	}
 
-	swagger, err := util.LoadSwaggerWithOverlay(flag.Arg(0), overlayOpts)
+	var swagger *openapi3.T
+	var specDigest string
+	var err error
+	if opts.OutputOptions.Fingerprint {
+		swagger, specDigest, err = util.LoadSwaggerWithOverlayAndDigest(flag.Arg(0), overlayOpts)
+	} else {
+		swagger, err = util.LoadSwaggerWithOverlay(flag.Arg(0), overlayOpts)
+	}
 	if err != nil {
 		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
 	}
 
+	if defaultTargets && util.IsModelsOnlySpec(swagger) {
+		opts.Generate = codegen.GenerateOptions{Models: true}
+	}
+
+	if opts.OutputOptions.Fingerprint {
+		opts.FingerprintSource, err = fingerprintSource(opts.OutputFile, flagConfigFile, flag.Arg(0), specDigest)
+		if err != nil {
+			errExit("configuration error: %v\n", err)
+		}
+	}
+
 	if len(noVCSVersionOverride) > 0 {
 		opts.NoVCSVersionOverride = &noVCSVersionOverride
 	}
 
-	code, genErr := codegen.Generate(swagger, opts.Configuration)
+	var code string
+	var sourceMap *codegen.SourceMap
+	var genErr error
+	switch {
+	case opts.SourceMapFile != "" && !flagCheck:
+		var result codegen.GenerateResult
+		result, genErr = codegen.GenerateWithSourceMap(swagger, opts.Configuration)
+		code, sourceMap = result.Code, result.SourceMap
+		diagnostics = append(diagnostics, result.Diagnostics...)
+	case flagDiagnosticsFormat == "json":
+		var generated []codegen.Diagnostic
+		code, generated, genErr = codegen.GenerateWithDiagnostics(swagger, opts.Configuration)
+		diagnostics = append(diagnostics, generated...)
+	default:
+		code, genErr = codegen.Generate(swagger, opts.Configuration)
+	}
+	if flagDiagnosticsFormat == "json" {
+		if diagnostics == nil {
+			diagnostics = []codegen.Diagnostic{}
+		}
+		if err := writeJSON(os.Stderr, diagnostics); err != nil {
+			errExit("error writing diagnostics: %s\n", err)
+		}
+	}
+
+	if flagCheck {
+		if genErr != nil {
+			generationFailed(genErr)
+		}
+		upToDate, err := checkOutput(os.Stdout, opts.OutputFile, code)
+		if err != nil {
+			errExit("error checking generated code: %s\n", err)
+		}
+		if !upToDate {
+			errExit("%s is out of date\n", opts.OutputFile)
+		}
+		return
+	}
 
 	// Always emit any generated code to the requested destination, even when
 	// generation returned an error (e.g. the formatter rejected the output).
@@ -346,9 +477,64 @@ func main() {
 		}
 	}
 
+	if sourceMap != nil {
+		if err := writeSourceMap(opts.SourceMapFile, sourceMap); err != nil {
+			errExit("error writing source map: %s\n", err)
+		}
+	}
+
 	if genErr != nil {
-		errExit("error generating code: %s\n", genErr)
+		generationFailed(genErr)
+	}
+}
+
+// writeWarnings writes the configuration warnings to w, by name, under the
+// heading, unless there are none.
+func writeWarnings(w io.Writer, heading string, warnings map[string]string) {
+	if len(warnings) == 0 {
+		return
+	}
+	var out strings.Builder
+	out.WriteString("WARNING: " + heading + ":\n")
+	for _, k := range slices.Sorted(maps.Keys(warnings)) {
+		out.WriteString("- " + k + ": " + warnings[k] + "\n")
+	}
+	_, _ = fmt.Fprint(w, out.String())
+}
+
+// generationFailed exits on err generating code, which, with the JSON
+// diagnostics format, is already reported as a diagnostic.
+func generationFailed(err error) {
+	if flagDiagnosticsFormat == "json" {
+		os.Exit(1)
+	}
+	errExit("error generating code: %s\n", err)
+}
+
+// checkOutput reports whether outputFile holds code, writing a unified diff
+// of the changes which regenerating it would make to w if it doesn't. A
+// missing outputFile isn't up to date.
+func checkOutput(w io.Writer, outputFile string, code string) (bool, error) {
+	current, err := os.ReadFile(outputFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if err == nil && string(current) == code {
+		return true, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(code),
+		FromFile: outputFile,
+		ToFile:   outputFile + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error computing diff: %w", err)
 	}
+	_, err = io.WriteString(w, diff)
+	return false, err
 }
 
 func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
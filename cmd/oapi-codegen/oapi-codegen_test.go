@@ -1,8 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 )
 
@@ -24,3 +34,430 @@ func TestLoader(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckOutput(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "api.gen.go")
+	if err := os.WriteFile(outputFile, []byte("package api\n\nconst a = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var diff strings.Builder
+	upToDate, err := checkOutput(&diff, outputFile, "package api\n\nconst a = 1\n")
+	if err != nil || !upToDate || diff.Len() != 0 {
+		t.Errorf("identical output: got %v, %v, %q", upToDate, err, diff.String())
+	}
+
+	upToDate, err = checkOutput(&diff, outputFile, "package api\n\nconst a = 2\n")
+	if err != nil || upToDate {
+		t.Errorf("changed output: got %v, %v", upToDate, err)
+	}
+	if !strings.Contains(diff.String(), "-const a = 1\n+const a = 2\n") {
+		t.Errorf("changed output: got diff %q", diff.String())
+	}
+
+	// A missing output file isn't up to date.
+	diff.Reset()
+	upToDate, err = checkOutput(&diff, filepath.Join(t.TempDir(), "missing.go"), "package api\n")
+	if err != nil || upToDate || !strings.Contains(diff.String(), "+package api\n") {
+		t.Errorf("missing output: got %v, %v, %q", upToDate, err, diff.String())
+	}
+}
+
+func TestWriteWarnings(t *testing.T) {
+	var out strings.Builder
+	writeWarnings(&out, "Some warnings", map[string]string{
+		"std-http-server": "the go.mod is too old",
+		"client":          "another",
+	})
+	// Each warning is on its own line, so that what's written next starts on
+	// a line of its own too.
+	want := "WARNING: Some warnings:\n- client: another\n- std-http-server: the go.mod is too old\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	writeWarnings(&out, "No warnings", nil)
+	if out.Len() != 0 {
+		t.Errorf("no warnings: got %q", out.String())
+	}
+}
+
+func TestCheckStale(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := "package: api\ngenerate:\n  models: true\noutput-options:\n  fingerprint: true\n"
+	writeFile("cfg.yaml", config)
+	writeFile("api/spec.yaml", `openapi: 3.0.0
+info: {title: API, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`)
+	writeFile("api/other.gen.go", "package api\n")
+
+	// Generate api/api.gen.go as `oapi-codegen -config cfg.yaml api/spec.yaml`
+	// does in dir.
+	var opts configuration
+	if err := yaml.Unmarshal([]byte(config), &opts); err != nil {
+		t.Fatal(err)
+	}
+	opts.Configuration = opts.UpdateDefaults()
+	swagger, digest, err := util.LoadSwaggerWithOverlayAndDigest(filepath.Join(dir, "api/spec.yaml"), util.LoadSwaggerWithOverlayOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.FingerprintSource = &codegen.FingerprintSource{SpecDigest: digest, Dir: "..", Config: "cfg.yaml", Spec: "api/spec.yaml"}
+	code, err := codegen.Generate(swagger, opts.Configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile("api/api.gen.go", code)
+
+	var out strings.Builder
+	stale, err := checkStale(&out, dir, false)
+	if err != nil || stale != 0 || out.Len() != 0 {
+		t.Errorf("up to date: got %d, %v, %q", stale, err, out.String())
+	}
+
+	// Changing the spec, or the configuration, makes the file stale.
+	writeFile("api/spec.yaml", strings.Replace(string(mustReadFile(t, filepath.Join(dir, "api/spec.yaml"))), "name:", "tag:", 1))
+	stale, err = checkStale(&out, dir, false)
+	if err != nil || stale != 1 || out.String() != filepath.Join(dir, "api", "api.gen.go")+" is stale\n" {
+		t.Errorf("changed spec: got %d, %v, %q", stale, err, out.String())
+	}
+	writeFile("api/spec.yaml", strings.Replace(string(mustReadFile(t, filepath.Join(dir, "api/spec.yaml"))), "tag:", "name:", 1))
+	writeFile("cfg.yaml", strings.Replace(config, "models: true", "models: true\n  client: true", 1))
+	out.Reset()
+	stale, err = checkStale(&out, dir, false)
+	if err != nil || stale != 1 {
+		t.Errorf("changed configuration: got %d, %v, %q", stale, err, out.String())
+	}
+}
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("cfg.yaml", "package: api\noutput-options:\n  include-tags: [pets]\n")
+	writeFile("common.yaml", `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`)
+	writeFile("spec.yaml", `openapi: 3.0.0
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "common.yaml#/components/schemas/Pet"}
+  /users:
+    get:
+      tags: [users]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/User"}
+components:
+  schemas:
+    User: {type: object}
+`)
+
+	outputPath := filepath.Join(dir, "out", "bundled.json")
+	if err := runBundle([]string{"-config", filepath.Join(dir, "cfg.yaml"), "-o", outputPath, filepath.Join(dir, "spec.yaml")}); err != nil {
+		t.Fatal(err)
+	}
+	bundled := mustReadFile(t, outputPath)
+	if strings.Contains(string(bundled), "common.yaml") {
+		t.Errorf("the bundled spec references common.yaml:\n%s", bundled)
+	}
+
+	// The bundle is JSON, as the output file's extension, filtered by tag,
+	// pruned, and self-contained.
+	swagger, err := openapi3.NewLoader().LoadFromData(bundled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swagger.Paths.Len() != 1 || swagger.Paths.Value("/pets") == nil {
+		t.Errorf("got paths %v, want /pets", swagger.Paths.InMatchingOrder())
+	}
+	if _, ok := swagger.Components.Schemas["User"]; ok {
+		t.Error("the unused schema User isn't pruned")
+	}
+	schema := swagger.Paths.Value("/pets").Get.Responses.Status(200).Value.Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/common_Pet" || schema.Value == nil {
+		t.Errorf("got the reference %q, want #/components/schemas/common_Pet", schema.Ref)
+	}
+}
+
+func TestLint(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(`openapi: 3.0.0
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: string}
+                patternProperties:
+                  "^x-": {type: string}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	failed, err := runLint([]string{"-format", "json", specPath}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed {
+		t.Errorf("the spec has no errors, but linting it failed:\n%s", out.String())
+	}
+	var diagnostics []codegen.Diagnostic
+	if err := json.Unmarshal([]byte(out.String()), &diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, d := range diagnostics {
+		codes = append(codes, d.Code)
+		if d.File != specPath || d.Line == 0 {
+			t.Errorf("the diagnostic %s isn't located in the spec", d)
+		}
+	}
+	if !slices.Contains(codes, codegen.CodeDefaultOperationID) || !slices.Contains(codes, codegen.CodeIgnoredPatternProperties) {
+		t.Errorf("got the diagnostics %v, want %s and %s", codes, codegen.CodeDefaultOperationID, codegen.CodeIgnoredPatternProperties)
+	}
+
+	out.Reset()
+	if _, err := runLint([]string{"-format", "sarif", specPath}, &out); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal([]byte(out.String()), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != len(diagnostics) {
+		t.Fatalf("got the SARIF log %s, want a run with %d results", out.String(), len(diagnostics))
+	}
+	for _, result := range log.Runs[0].Results {
+		if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.Region == nil {
+			t.Errorf("the result %q has no region", result.Message.Text)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(`openapi: 3.0.0
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string, x-go-name: PetName}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := runExplain([]string{specPath, "Pet.PetName"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "Pet.PetName (field)\n  from: /components/schemas/Pet/properties/name\n  at:   " + specPath + ":18:9\n  rule: named by its x-go-name\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// The identifier is looked up in a source map written when generating
+	// code.
+	opts, err := readConfiguration("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger, err := util.LoadSwagger(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := codegen.GenerateWithSourceMap(swagger, opts.Configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceMapPath := filepath.Join(dir, "out", "api.map.json")
+	if err := writeSourceMap(sourceMapPath, result.SourceMap); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runExplain([]string{"-source-map", sourceMapPath, "ListPets"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "ServerInterface.ListPets (method)\n  from: /paths/~1pets/get\n") {
+		t.Errorf("ServerInterface.ListPets isn't explained:\n%s", out.String())
+	}
+
+	if err := runExplain([]string{specPath, "Client"}, &out); err == nil {
+		t.Error("Client isn't generated from the spec, but it's explained")
+	}
+}
+
+func TestWatchedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"spec.yaml": `openapi: 3.0.0
+info: {title: API, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet: {$ref: "schemas/pet.yaml"}
+`,
+		"schemas/pet.yaml": "type: object\n",
+		"overlay.yaml": `overlay: 1.0.0
+info: {title: Overlay, version: "1"}
+actions:
+  - target: $.info
+    update: {description: API}
+`,
+		"templates/client.tmpl":  "",
+		"config.yaml":            "package: api\noutput-options:\n  overlay: {path: overlay.yaml}\n  user-templates: {client.tmpl: templates/client.tmpl}\n",
+		"old/templates/dir.tmpl": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	got := watchedFiles("config.yaml", "spec.yaml")
+	want := []string{"config.yaml", "overlay.yaml", filepath.Join("schemas", "pet.yaml"), "spec.yaml", filepath.Join("templates", "client.tmpl")}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// The files the spec is loaded from are watched even when it fails to
+	// load.
+	if err := os.WriteFile("schemas/pet.yaml", []byte("type: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("old.yaml", []byte("package: api\ngenerate: [types]\ntemplates: old/templates\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got = watchedFiles("old.yaml", "spec.yaml")
+	want = []string{"old.yaml", filepath.Join("old", "templates"), filepath.Join("schemas", "pet.yaml"), "spec.yaml"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	interval, debounce := watchInterval, watchDebounce
+	watchInterval, watchDebounce = 10*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { watchInterval, watchDebounce = interval, debounce })
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := make(chan int, 10)
+	generations := 0
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var out strings.Builder
+	go func() {
+		defer close(done)
+		watch(&out, func() []string { return []string{path} }, func() error {
+			generations++
+			generated <- generations
+			// Failing to generate doesn't stop watching.
+			if generations == 1 {
+				return os.ErrInvalid
+			}
+			return nil
+		}, stop)
+	}()
+
+	<-generated
+	// A burst of changes regenerates once.
+	for _, content := range []string{"ab", "abc", "abcd"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case <-generated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change isn't regenerated")
+	}
+	select {
+	case n := <-generated:
+		t.Errorf("regenerated %d times", n)
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(stop)
+	<-done
+
+	want := "generation failed: invalid argument\nwatching 1 files for changes\n" + path + " changed, regenerating\nwatching 1 files for changes\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
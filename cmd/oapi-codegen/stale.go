@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// fingerprintFlags are the flags which may be used with
+// output-options.fingerprint, whose fingerprint only records the
+// configuration file, the spec and the output file.
+var fingerprintFlags = map[string]bool{
+	"o":      true,
+	"config": true,
+	"check":  true,
+}
+
+// fingerprintSource returns the source of the fingerprint of the code
+// generated into outputFile, from specPath with the configuration file at
+// configPath, whose spec digest is specDigest.
+func fingerprintSource(outputFile, configPath, specPath, specDigest string) (*codegen.FingerprintSource, error) {
+	var unrecorded []string
+	flag.Visit(func(f *flag.Flag) {
+		if !fingerprintFlags[f.Name] {
+			unrecorded = append(unrecorded, "-"+f.Name)
+		}
+	})
+	if len(unrecorded) > 0 {
+		return nil, fmt.Errorf("output-options.fingerprint only records the configuration file, so %s can't be used with it; set it in the configuration instead", strings.Join(unrecorded, ", "))
+	}
+	if outputFile == "" {
+		return nil, fmt.Errorf("output-options.fingerprint requires an output file, set with -o or the configuration's output")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	outputDir, err := filepath.Abs(filepath.Dir(outputFile))
+	if err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Rel(outputDir, wd)
+	if err != nil {
+		return nil, err
+	}
+	return &codegen.FingerprintSource{
+		SpecDigest: specDigest,
+		Dir:        filepath.ToSlash(dir),
+		Config:     filepath.ToSlash(configPath),
+		Spec:       filepath.ToSlash(specPath),
+	}, nil
+}
+
+// checkStale walks root for generated files, named `*.gen.go`, whose header
+// records a fingerprint, and writes the ones whose inputs have changed
+// since to w, regenerating them with regenerate. It returns the number of
+// stale files.
+func checkStale(w io.Writer, root string, regenerate bool) (int, error) {
+	stale := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (d.Name() == "vendor" || d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".gen.go") {
+			return nil
+		}
+
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fingerprint, ok, err := codegen.ParseFingerprint(code)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if !ok {
+			return nil
+		}
+		dir := filepath.Join(filepath.Dir(path), filepath.FromSlash(fingerprint.Dir))
+		hash, err := inputsHash(dir, fingerprint)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if hash == fingerprint.Hash {
+			return nil
+		}
+
+		stale++
+		if !regenerate {
+			_, err = fmt.Fprintf(w, "%s is stale\n", path)
+			return err
+		}
+		if err := regenerateFile(dir, path, fingerprint); err != nil {
+			return fmt.Errorf("error regenerating %s: %w", path, err)
+		}
+		_, err = fmt.Fprintf(w, "%s regenerated\n", path)
+		return err
+	})
+	return stale, err
+}
+
+// inputsHash returns the hash of the current inputs of the code whose header
+// records fingerprint, generated in dir.
+func inputsHash(dir string, fingerprint codegen.Fingerprint) (string, error) {
+	if fingerprint.Config == "" {
+		return "", fmt.Errorf("the fingerprint records no configuration file")
+	}
+	configPath := resolvePath(dir, fingerprint.Config)
+	buf, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading config file '%s': %w", configPath, err)
+	}
+	var opts configuration
+	if err := yaml.Unmarshal(buf, &opts); err != nil {
+		return "", fmt.Errorf("error parsing '%s' as YAML: %w", configPath, err)
+	}
+	opts.Configuration = opts.UpdateDefaults()
+	if len(noVCSVersionOverride) > 0 {
+		opts.NoVCSVersionOverride = &noVCSVersionOverride
+	}
+
+	overlayOpts := util.LoadSwaggerWithOverlayOpts{
+		Strict: true,
+	}
+	if opts.OutputOptions.Overlay.Path != "" {
+		overlayOpts.Path = resolvePath(dir, opts.OutputOptions.Overlay.Path)
+	}
+	if opts.OutputOptions.Overlay.Strict != nil {
+		overlayOpts.Strict = *opts.OutputOptions.Overlay.Strict
+	}
+	specPath := resolvePath(dir, fingerprint.Spec)
+	_, digest, err := util.LoadSwaggerWithOverlayAndDigest(specPath, overlayOpts)
+	if err != nil {
+		return "", fmt.Errorf("error loading swagger spec in %s: %w", specPath, err)
+	}
+	return codegen.InputsHash(opts.Configuration, digest)
+}
+
+// regenerateFile regenerates the code at path, whose header records
+// fingerprint, running oapi-codegen in dir as it was run to generate it.
+func regenerateFile(dir, path string, fingerprint codegen.Fingerprint) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(executable, "-config", fingerprint.Config, "-o", absPath, fingerprint.Spec)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// resolvePath returns path, relative to dir unless it's absolute or a URL.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) || strings.Contains(path, "://") {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+var (
+	// watchInterval is how often the watched files are polled for changes.
+	watchInterval = 250 * time.Millisecond
+	// watchDebounce is how long the watched files must be unchanged for
+	// before regenerating, so that a burst of changes regenerates once.
+	watchDebounce = 500 * time.Millisecond
+)
+
+// watchedFiles returns the files which the code generated from specPath,
+// with the configuration file at configFile, is generated from: the
+// configuration file, the spec and the documents of its external
+// references, the Overlay, and the user templates, unless they're URLs.
+func watchedFiles(configFile, specPath string) []string {
+	var files []string
+	if !strings.Contains(specPath, "://") {
+		files = append(files, specPath)
+	}
+	overlayOpts := util.LoadSwaggerWithOverlayOpts{Strict: true}
+	if flagTemplatesDir != "" {
+		files = append(files, flagTemplatesDir)
+	}
+
+	if configFile != "" {
+		files = append(files, configFile)
+		// The configuration may be of either style, and may be invalid while
+		// it's edited, so whatever parses of it is used.
+		if buf, err := os.ReadFile(configFile); err == nil {
+			var opts configuration
+			_ = yaml.Unmarshal(buf, &opts)
+			overlayOpts = overlayOptions(opts)
+			overlayOpts.Warn = nil
+			if overlayOpts.Path != "" {
+				files = append(files, overlayOpts.Path)
+			}
+			for _, template := range opts.OutputOptions.UserTemplates {
+				// Templates which span lines are given inline, and the
+				// others are files or URLs.
+				if !strings.Contains(template, "\n") && !strings.Contains(template, "://") {
+					files = append(files, template)
+				}
+			}
+
+			var oldConfig oldConfiguration
+			_ = yaml.Unmarshal(buf, &oldConfig)
+			if oldConfig.TemplatesDir != "" {
+				files = append(files, oldConfig.TemplatesDir)
+			}
+		}
+	}
+
+	// The spec which fails to load is watched as far as it was read.
+	_, loaded, _ := util.LoadSwaggerWithOverlayAndFiles(specPath, overlayOpts)
+	files = append(files, loaded...)
+
+	for i, file := range files {
+		files[i] = filepath.Clean(file)
+	}
+	slices.Sort(files)
+	return slices.Compact(files)
+}
+
+// fileState is the state of a watched file which changes whenever the file
+// does.
+type fileState struct {
+	modTime int64
+	size    int64
+}
+
+// snapshotFiles returns the states of files, and of the files within those
+// which are directories. Missing files have no state.
+func snapshotFiles(files []string) map[string]fileState {
+	states := map[string]fileState{}
+	for _, file := range files {
+		_ = filepath.WalkDir(file, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			states[path] = fileState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+			return nil
+		})
+	}
+	return states
+}
+
+// changedFile returns one of the files whose states differ between before
+// and after.
+func changedFile(before, after map[string]fileState) string {
+	for path, state := range after {
+		if previous, ok := before[path]; !ok || previous != state {
+			return path
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// watch calls generate, and again whenever the files returned by inputs
+// change, once they've been unchanged for watchDebounce, writing what it
+// does to w. Errors of generate are written to w rather than returned, so
+// that it keeps on watching until stop is closed.
+func watch(w io.Writer, inputs func() []string, generate func() error, stop <-chan struct{}) {
+	for {
+		if err := generate(); err != nil {
+			_, _ = fmt.Fprintf(w, "generation failed: %s\n", err)
+		}
+
+		files := inputs()
+		_, _ = fmt.Fprintf(w, "watching %d files for changes\n", len(files))
+		states := snapshotFiles(files)
+
+		// Wait for a change, and then for the files to settle.
+		var changed string
+		settled := time.Time{}
+		for changed == "" || time.Now().Before(settled) {
+			select {
+			case <-stop:
+				return
+			case <-time.After(watchInterval):
+			}
+			current := snapshotFiles(files)
+			if path := changedFile(states, current); path != "" {
+				if changed == "" {
+					changed = path
+				}
+				settled = time.Now().Add(watchDebounce)
+			}
+			states = current
+		}
+		_, _ = fmt.Fprintf(w, "%s changed, regenerating\n", changed)
+	}
+}
+
+// regenerateCommand returns the command running executable, which generates
+// code as this one does with the arguments args, without -watch.
+func regenerateCommand(executable string, args []string) *exec.Cmd {
+	var regenerateArgs []string
+	for _, arg := range args {
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && name == "watch" {
+			continue
+		}
+		regenerateArgs = append(regenerateArgs, arg)
+	}
+	cmd := exec.Command(executable, regenerateArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// runWatch generates code from specPath, with the arguments args, and
+// regenerates it whenever its inputs change, until it's interrupted.
+func runWatch(args []string, configFile, specPath string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	watch(os.Stderr, func() []string {
+		return watchedFiles(configFile, specPath)
+	}, func() error {
+		return regenerateCommand(executable, args).Run()
+	}, nil)
+	return nil
+}
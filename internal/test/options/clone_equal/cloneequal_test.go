@@ -0,0 +1,57 @@
+package cloneequal
+
+import (
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNilEqualsEmpty(t *testing.T) {
+	a := Pet{Name: "Fido", Tags: new([]string), Labels: new(map[string]string)}
+	b := Pet{Name: "Fido", Tags: &[]string{}, Labels: &map[string]string{}}
+	assert.True(t, a.Equal(b))
+	assert.True(t, b.Equal(a))
+
+	// Missing properties are still different from empty ones.
+	assert.False(t, Pet{Name: "Fido"}.Equal(b))
+
+	assert.True(t, EqualPets(nil, Pets{}))
+	assert.False(t, EqualPets(nil, Pets{{Name: "Fido"}}))
+}
+
+func TestDecimalsEqualByValue(t *testing.T) {
+	price, err := types.ParseDecimal("1.0")
+	require.NoError(t, err)
+	samePrice, err := types.ParseDecimal("1.00")
+	require.NoError(t, err)
+	weight, err := types.ParseDecimalNumber("1e2")
+	require.NoError(t, err)
+	sameWeight, err := types.ParseDecimalNumber("100")
+	require.NoError(t, err)
+
+	a := Pet{Name: "Fido", Price: &price, Weight: &weight}
+	b := Pet{Name: "Fido", Price: &samePrice, Weight: &sameWeight}
+	assert.True(t, a.Equal(b))
+
+	otherPrice, err := types.ParseDecimal("1.01")
+	require.NoError(t, err)
+	b.Price = &otherPrice
+	assert.False(t, a.Equal(b))
+}
+
+func TestClonePets(t *testing.T) {
+	pets := Pets{{Name: "Fido", Tags: &[]string{"dog"}}}
+	clone := ClonePets(pets)
+	assert.True(t, EqualPets(pets, clone))
+
+	// The clone shares nothing with the original.
+	(*clone[0].Tags)[0] = "cat"
+	clone[0].Name = "Felix"
+	assert.Equal(t, "dog", (*pets[0].Tags)[0])
+	assert.Equal(t, "Fido", pets[0].Name)
+	assert.False(t, EqualPets(pets, clone))
+
+	assert.Nil(t, ClonePets(nil))
+}
@@ -0,0 +1,7 @@
+// Package cloneequal exercises output-options.clone-and-equal: nil slices
+// and maps are equal to empty ones, decimals compare by their value rather
+// than their digits, and the type alias Pets, which can't have methods, gets
+// ClonePets and EqualPets functions.
+package cloneequal
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
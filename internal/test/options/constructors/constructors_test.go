@@ -0,0 +1,27 @@
+package constructors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The constructors of the schemas which are named as the client's request
+// builders build the bodies those take.
+func TestConstructorsWithClient(t *testing.T) {
+	req, err := NewCreatePetRequest("https://example.com", MakeCreatePetRequest("Fido", WithCreatePetRequestTag("dog")))
+	require.NoError(t, err)
+	var body CreatePetRequest
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+	assert.Equal(t, "Fido", body.Name)
+	require.NotNil(t, body.Tag)
+	assert.Equal(t, "dog", *body.Tag)
+
+	req, err = NewReindexRequest("https://example.com", MakeReindexRequest(WithReindexRequestFull(true)))
+	require.NoError(t, err)
+	assert.Equal(t, "/admin/reindex", req.URL.Path)
+
+	assert.Equal(t, Pet{Id: 1, Name: "Fido"}, NewPet(1, "Fido"))
+}
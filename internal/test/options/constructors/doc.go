@@ -0,0 +1,9 @@
+// Package constructors exercises output-options.constructors alongside the
+// client and servers: the schemas CreatePetRequest and ReindexRequest share
+// their names with the request bodies of the createPet and reindex
+// operations, whose client request builders are NewCreatePetRequest and
+// NewReindexRequest, so their constructors fall back to MakeCreatePetRequest
+// and MakeReindexRequest. The package only compiles if they don't collide.
+package constructors
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
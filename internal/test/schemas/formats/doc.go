@@ -0,0 +1,8 @@
+// Package schemasformats exercises output-options.format-types, which maps
+// string and number formats to the types of pkg/types: TimeOfDay for
+// `format: time`, which keeps an RFC 3339 time-offset when there is one, and
+// Decimal and DecimalNumber for `format: decimal` strings and numbers, which
+// keep their digits.
+package schemasformats
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,29 @@
+package schemasformats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatsJSON(t *testing.T) {
+	var opening Opening
+	require.NoError(t, json.Unmarshal([]byte(`{"opens":"09:30:00+02:00","price":"12.50","total":0.5e+3}`), &opening))
+	assert.Equal(t, "12.50", opening.Price.String())
+	assert.Equal(t, "0.5e+3", opening.Total.String())
+
+	// Decimal strings stay strings, and decimal numbers are written as
+	// valid JSON numbers.
+	out, err := json.Marshal(opening)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"opens":"09:30:00+02:00","price":"12.50","total":0.5e+3}`, string(out))
+	assert.Contains(t, string(out), `"total":0.5e+3`)
+
+	// Decimal numbers may also be given as strings.
+	require.NoError(t, json.Unmarshal([]byte(`{"opens":"09:30:00","price":"1","total":"-0012.340"}`), &opening))
+	out, err = json.Marshal(opening.Total)
+	require.NoError(t, err)
+	assert.Equal(t, "-12.340", string(out))
+}
@@ -0,0 +1,7 @@
+// Package contentnegotiation exercises output-options.strict-content-negotiation
+// on the std-http strict server: the representation of a response with
+// several content types which the request's Accept header prefers is sent,
+// and a 406 Not Acceptable when it accepts none of them.
+package contentnegotiation
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml spec.yaml
@@ -0,0 +1,103 @@
+package contentnegotiation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// server answers GetPet with getPet, and records the negotiated content type
+// of the request.
+type server struct {
+	getPet     func(request GetPetRequestObject) GetPetResponseObject
+	negotiated string
+}
+
+func (s *server) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	s.negotiated = request.NegotiatedContentType
+	return s.getPet(request), nil
+}
+
+func (s *server) ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error) {
+	return ListPets200JSONResponse{{Name: "Fido"}}, nil
+}
+
+// allRepresentations returns the pet in each of the content types of the 200
+// response.
+func allRepresentations(request GetPetRequestObject) GetPetResponseObject {
+	return GetPet200MultiResponse{
+		JSON:           &GetPet200JSONResponse{Name: "Fido"},
+		Applicationxml: &GetPet200ApplicationxmlResponse{Body: strings.NewReader("<pet><name>Fido</name></pet>")},
+		Textcsv:        &GetPet200TextcsvResponse{Body: strings.NewReader("name\nFido")},
+	}
+}
+
+func TestContentNegotiation(t *testing.T) {
+	s := &server{getPet: allRepresentations}
+	h := Handler(NewStrictHandler(s, nil))
+	do := func(path, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return rec
+	}
+
+	for _, tc := range []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		// Without an Accept header, the first content type is sent.
+		{accept: "", contentType: "application/json", body: `{"name":"Fido"}`},
+		{accept: "*/*", contentType: "application/json", body: `{"name":"Fido"}`},
+		{accept: "application/xml", contentType: "application/xml", body: "<pet><name>Fido</name></pet>"},
+		{accept: "text/*", contentType: "text/csv", body: "name\nFido"},
+		{accept: "application/json;q=0.5, text/csv;q=0.9", contentType: "text/csv", body: "name\nFido"},
+		// The most specific media range gives the quality.
+		{accept: "application/*;q=0.1, application/json, */*;q=0.5", contentType: "application/json", body: `{"name":"Fido"}`},
+	} {
+		t.Run(tc.accept, func(t *testing.T) {
+			rec := do("/pets/1", tc.accept)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.contentType, s.negotiated)
+			assert.Equal(t, tc.body, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+
+	// A request accepting none of the content types of the operation's
+	// responses isn't handled.
+	s.negotiated = "unset"
+	rec := do("/pets/1", "image/png")
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	assert.Equal(t, "unset", s.negotiated)
+
+	// Nor is one accepting none of the representations the handler gives.
+	s.getPet = func(request GetPetRequestObject) GetPetResponseObject {
+		return GetPet200MultiResponse{JSON: &GetPet200JSONResponse{Name: "Fido"}}
+	}
+	rec = do("/pets/1", "text/csv")
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	rec = do("/pets/1", "text/csv, application/json;q=0.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	// Responses with a single content type are sent as they are.
+	s.getPet = func(request GetPetRequestObject) GetPetResponseObject {
+		return GetPet404JSONResponse{Message: "no such pet"}
+	}
+	rec = do("/pets/1", "application/xml")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	rec = do("/pets", "text/csv")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
@@ -0,0 +1,5 @@
+// Package fiber exercises output-options.cors on the fiber server, whose
+// responses have no http.Header to add `Vary: Origin` to, so append it.
+package fiber
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,51 @@
+package fiber
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) ListPets(c *fiber.Ctx, params ListPetsParams) error {
+	return c.SendString("pets")
+}
+
+func (server) AddPet(c *fiber.Ctx) error {
+	return c.SendStatus(http.StatusCreated)
+}
+
+func TestCORS(t *testing.T) {
+	app := fiber.New()
+	// A middleware which varies the response by another header.
+	app.Use(func(c *fiber.Ctx) error {
+		c.Set("Vary", "Accept-Encoding")
+		return c.Next()
+	})
+	RegisterHandlersWithOptions(app, server{}, FiberServerOptions{
+		CORS: CORSOptions{AllowedOrigins: []string{"*"}},
+	})
+	do := func(method string) *http.Response {
+		r := httptest.NewRequest(method, "/pets", nil)
+		r.Header.Set("Origin", "https://example.com")
+		resp, err := app.Test(r)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do(http.MethodOptions)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "GET, POST, OPTIONS", resp.Header.Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Accept-Encoding, Origin", resp.Header.Get("Vary"))
+
+	resp = do(http.MethodGet)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Accept-Encoding, Origin", resp.Header.Get("Vary"))
+}
@@ -0,0 +1,7 @@
+// Package stdhttp exercises output-options.cors with head-from-get on the
+// std-http server: preflight requests are answered with the path's methods,
+// including the HEAD which its GET operation serves, and `Vary: Origin` is
+// added to the response's other `Vary` values rather than replacing them.
+package stdhttp
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
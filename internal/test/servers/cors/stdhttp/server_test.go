@@ -0,0 +1,77 @@
+package stdhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams) {
+	_, _ = w.Write([]byte("pets"))
+}
+
+func (server) AddPet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestCORS(t *testing.T) {
+	api := HandlerWithOptions(server{}, StdHTTPServerOptions{
+		CORS: CORSOptions{
+			AllowedOrigins: []string{"https://example.com"},
+			AllowedHeaders: []string{"X-Extra"},
+			MaxAge:         time.Minute,
+		},
+	})
+	// A middleware, such as a compressing one, which varies the response by
+	// another header.
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept-Encoding")
+		api.ServeHTTP(w, r)
+	})
+	do := func(method, origin string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/pets", nil)
+		r.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return rec
+	}
+
+	t.Run("preflight", func(t *testing.T) {
+		rec := do(http.MethodOptions, "https://example.com")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		// The GET operation serves HEAD with head-from-get.
+		assert.Equal(t, "GET, HEAD, POST, OPTIONS", rec.Header().Get("Allow"))
+		assert.Equal(t, "GET, HEAD, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "X-Request-ID, Content-Type, X-Extra", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "60", rec.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Accept-Encoding", "Origin"}, rec.Header().Values("Vary"))
+	})
+
+	t.Run("response", func(t *testing.T) {
+		rec := do(http.MethodGet, "https://example.com")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		// Vary keeps the value of the middleware.
+		assert.Equal(t, []string{"Accept-Encoding", "Origin"}, rec.Header().Values("Vary"))
+
+		rec = do(http.MethodHead, "https://example.com")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		rec := do(http.MethodOptions, "https://evil.example")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "GET, HEAD, POST, OPTIONS", rec.Header().Get("Allow"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+		// The answer still depends on the origin.
+		assert.Equal(t, []string{"Accept-Encoding", "Origin"}, rec.Header().Values("Vary"))
+	})
+}
@@ -0,0 +1,7 @@
+// Package chi exercises output-options.method-not-allowed with
+// head-from-get and problem-details on the chi server, which registers a
+// handler answering the methods of the API which none of the operations of
+// a path has with a 405 problem details response.
+package chi
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,59 @@
+package chi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) ListPets(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Pets", "3")
+	_, _ = w.Write([]byte("pets"))
+}
+
+func (server) AddPet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (server) DeletePet(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	h := Handler(server{})
+	do := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	// With head-from-get, HEAD is served by the GET operation.
+	rec := do(http.MethodHead, "/pets")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("X-Pets"))
+
+	// The methods of the API which none of the operations of a path has are
+	// answered with a 405 problem details response listing its methods.
+	rec = do(http.MethodDelete, "/pets")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Allow"))
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	var problem ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, http.StatusMethodNotAllowed, problem.Status)
+	assert.Equal(t, "method DELETE is not allowed", problem.Detail)
+	assert.Equal(t, "/pets", problem.Instance)
+
+	rec = do(http.MethodPost, "/pets/1")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "DELETE", rec.Header().Get("Allow"))
+
+	rec = do(http.MethodDelete, "/pets/1")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
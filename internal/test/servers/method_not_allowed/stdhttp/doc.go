@@ -0,0 +1,8 @@
+// Package stdhttp exercises output-options.method-not-allowed with the
+// std-http server, whose ServeMux answers the requests of a path with a
+// method which none of its operations has with a 405 itself, and serves the
+// HEAD requests of a path with a GET operation with it, without
+// head-from-get.
+package stdhttp
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
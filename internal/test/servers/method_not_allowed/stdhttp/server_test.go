@@ -0,0 +1,50 @@
+package stdhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct{}
+
+func (server) ListPets(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Pets", "3")
+	_, _ = w.Write([]byte("pets"))
+}
+
+func (server) AddPet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (server) DeletePet(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	h := Handler(server{})
+	do := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	// ServeMux serves HEAD with the GET operation, without its body.
+	rec := do(http.MethodHead, "/pets")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("X-Pets"))
+
+	// The other methods are answered with a 405 listing the path's methods.
+	rec = do(http.MethodDelete, "/pets")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Allow"))
+
+	rec = do(http.MethodGet, "/pets/1")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "DELETE", rec.Header().Get("Allow"))
+
+	rec = do(http.MethodDelete, "/pets/1")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
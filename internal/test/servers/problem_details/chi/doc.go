@@ -0,0 +1,4 @@
+// Package chi exercises output-options.problem-details on the chi strict server.
+package chi
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
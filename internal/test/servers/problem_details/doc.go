@@ -0,0 +1,5 @@
+// Package problemdetails exercises output-options.problem-details on every
+// server: one shared spec (spec.yaml), and one table-driven test
+// (problem_details_test.go) run against the strict server of each router.
+// The echo and std-http servers have tests of their own.
+package problemdetails
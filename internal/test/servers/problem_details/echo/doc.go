@@ -0,0 +1,6 @@
+// Package echo exercises output-options.problem-details on the echo server,
+// which writes its parameter errors as problem details directly, rather than
+// through an error handler.
+package echo
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
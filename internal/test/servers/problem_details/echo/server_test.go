@@ -0,0 +1,71 @@
+package echo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) AddPet(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (server) GetPet(ctx echo.Context, id int, params GetPetParams) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func TestProblemDetails(t *testing.T) {
+	e := echo.New()
+	RegisterHandlers(e, server{})
+
+	for name, tc := range map[string]struct {
+		target     string
+		requestIDs []string
+		want       ProblemDetailsInvalidParam
+	}{
+		"invalid path parameter": {
+			target:     "/pets/abc?limit=1",
+			requestIDs: []string{"r1"},
+			want:       ProblemDetailsInvalidParam{Name: "id", In: "path"},
+		},
+		"invalid query parameter": {
+			target:     "/pets/1?limit=many",
+			requestIDs: []string{"r1"},
+			want:       ProblemDetailsInvalidParam{Name: "limit", In: "query"},
+		},
+		"missing header": {
+			target: "/pets/1?limit=1",
+			want:   ProblemDetailsInvalidParam{Name: "X-Request-Id", In: "header"},
+		},
+		"repeated header": {
+			target:     "/pets/1?limit=1",
+			requestIDs: []string{"r1", "r2"},
+			want:       ProblemDetailsInvalidParam{Name: "X-Request-Id", In: "header"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			for _, id := range tc.requestIDs {
+				r.Header.Add("X-Request-Id", id)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, r)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+			var problem ProblemDetails
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+			assert.Equal(t, r.URL.Path, problem.Instance)
+			require.Len(t, problem.InvalidParams, 1)
+			assert.Equal(t, tc.want.Name, problem.InvalidParams[0].Name)
+			assert.Equal(t, tc.want.In, problem.InvalidParams[0].In)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package echo5 exercises output-options.problem-details on the echo5 strict server.
+package echo5
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
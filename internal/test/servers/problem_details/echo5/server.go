@@ -0,0 +1,15 @@
+package echo5
+
+import "context"
+
+// StrictServer accepts every request which the generated server lets
+// through.
+type StrictServer struct{}
+
+func (StrictServer) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return AddPet201Response{}, nil
+}
+
+func (StrictServer) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	return GetPet204Response{}, nil
+}
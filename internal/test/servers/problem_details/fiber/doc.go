@@ -0,0 +1,4 @@
+// Package fiber exercises output-options.problem-details on the fiber strict server.
+package fiber
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,4 @@
+// Package fiberv3 exercises output-options.problem-details on the fiberv3 strict server.
+package fiberv3
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
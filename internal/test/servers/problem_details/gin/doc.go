@@ -0,0 +1,4 @@
+// Package gin exercises output-options.problem-details on the gin strict server.
+package gin
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,4 @@
+// Package gorilla exercises output-options.problem-details on the gorilla strict server.
+package gorilla
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,4 @@
+// Package iris exercises output-options.problem-details on the iris strict server.
+package iris
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,143 @@
+package problemdetails
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofiber/fiber/v2"
+	fiberadaptor "github.com/gofiber/fiber/v2/middleware/adaptor"
+	fiberv3 "github.com/gofiber/fiber/v3"
+	fiberv3adaptor "github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/gorilla/mux"
+	"github.com/kataras/iris/v12"
+	echov5 "github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chiAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/chi"
+	echo5API "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/echo5"
+	fiberAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/fiber"
+	fiberv3API "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/fiberv3"
+	ginAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/gin"
+	gorillaAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/gorilla"
+	irisAPI "github.com/oapi-codegen/oapi-codegen/v2/internal/test/servers/problem_details/iris"
+)
+
+func TestChiServer(t *testing.T) {
+	r := chi.NewRouter()
+	testImpl(t, chiAPI.HandlerFromMux(chiAPI.NewStrictHandler(chiAPI.StrictServer{}, nil), r), false)
+}
+
+func TestGorillaServer(t *testing.T) {
+	r := mux.NewRouter()
+	testImpl(t, gorillaAPI.HandlerFromMux(gorillaAPI.NewStrictHandler(gorillaAPI.StrictServer{}, nil), r), false)
+}
+
+func TestGinServer(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	ginAPI.RegisterHandlers(r, ginAPI.NewStrictHandler(ginAPI.StrictServer{}, nil))
+	testImpl(t, r, false)
+}
+
+func TestFiberServer(t *testing.T) {
+	app := fiber.New()
+	fiberAPI.RegisterHandlers(app, fiberAPI.NewStrictHandler(fiberAPI.StrictServer{}, nil))
+	testImpl(t, fiberadaptor.FiberApp(app), true)
+}
+
+func TestFiberV3Server(t *testing.T) {
+	app := fiberv3.New()
+	fiberv3API.RegisterHandlers(app, fiberv3API.NewStrictHandler(fiberv3API.StrictServer{}, nil))
+	testImpl(t, fiberv3adaptor.FiberApp(app), true)
+}
+
+func TestIrisServer(t *testing.T) {
+	i := iris.New()
+	irisAPI.RegisterHandlers(i, irisAPI.NewStrictHandler(irisAPI.StrictServer{}, nil))
+	require.NoError(t, i.Build())
+	testImpl(t, i, false)
+}
+
+func TestEcho5Server(t *testing.T) {
+	e := echov5.New()
+	echo5API.RegisterHandlers(e, echo5API.NewStrictHandler(echo5API.StrictServer{}, nil))
+	testImpl(t, e, false)
+}
+
+// problem is the part of a problem details response which every server
+// writes alike.
+type problem struct {
+	Status        int    `json:"status"`
+	Detail        string `json:"detail"`
+	Instance      string `json:"instance"`
+	InvalidParams []struct {
+		Name   string `json:"name"`
+		In     string `json:"in"`
+		Reason string `json:"reason"`
+	} `json:"invalid-params"`
+}
+
+// testImpl runs the requests against handler. The Fiber adaptors keep only
+// the last value of a repeated header, so they skip that case.
+func testImpl(t *testing.T, handler http.Handler, skipRepeatedHeader bool) {
+	do := func(t *testing.T, r *http.Request) problem {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		var p problem
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&p))
+		assert.Equal(t, http.StatusBadRequest, p.Status)
+		assert.Equal(t, r.URL.Path, p.Instance)
+		return p
+	}
+	getPet := func(target string, requestIDs ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for _, id := range requestIDs {
+			r.Header.Add("X-Request-Id", id)
+		}
+		return r
+	}
+
+	for name, tc := range map[string]struct {
+		r        *http.Request
+		wantName string
+		wantIn   string
+	}{
+		"invalid path parameter":  {r: getPet("/pets/abc?limit=1", "r1"), wantName: "id", wantIn: "path"},
+		"invalid query parameter": {r: getPet("/pets/1?limit=many", "r1"), wantName: "limit", wantIn: "query"},
+		"missing query parameter": {r: getPet("/pets/1", "r1"), wantName: "limit", wantIn: "query"},
+		"missing header":          {r: getPet("/pets/1?limit=1"), wantName: "X-Request-Id", wantIn: "header"},
+		"repeated header":         {r: getPet("/pets/1?limit=1", "r1", "r2"), wantName: "X-Request-Id", wantIn: "header"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if name == "repeated header" && skipRepeatedHeader {
+				t.Skip("the adaptor drops all but the last value of a repeated header")
+			}
+			p := do(t, tc.r)
+			require.Len(t, p.InvalidParams, 1)
+			assert.Equal(t, tc.wantName, p.InvalidParams[0].Name)
+			assert.Equal(t, tc.wantIn, p.InvalidParams[0].In)
+			assert.NotEmpty(t, p.InvalidParams[0].Reason)
+		})
+	}
+
+	t.Run("invalid body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader("{"))
+		r.Header.Set("Content-Type", "application/json")
+		p := do(t, r)
+		assert.Empty(t, p.InvalidParams)
+		assert.NotEmpty(t, p.Detail)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, getPet("/pets/1?limit=1", "r1"))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
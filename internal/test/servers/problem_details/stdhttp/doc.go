@@ -0,0 +1,6 @@
+// Package stdhttp exercises output-options.problem-details on the std-http
+// strict server, which answers invalid parameters and request bodies with
+// problem details listing the parameters, and where they are.
+package stdhttp
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../spec.yaml
@@ -0,0 +1,86 @@
+package stdhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return AddPet201Response{}, nil
+}
+
+func (server) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	return GetPet204Response{}, nil
+}
+
+func TestProblemDetails(t *testing.T) {
+	h := Handler(NewStrictHandler(server{}, nil))
+	do := func(r *http.Request) ProblemDetails {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		var problem ProblemDetails
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, r.URL.Path, problem.Instance)
+		return problem
+	}
+	getPet := func(target string, requestIDs ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for _, id := range requestIDs {
+			r.Header.Add("X-Request-Id", id)
+		}
+		return r
+	}
+
+	for name, tc := range map[string]struct {
+		r    *http.Request
+		want ProblemDetailsInvalidParam
+	}{
+		"invalid path parameter": {
+			r:    getPet("/pets/abc?limit=1", "r1"),
+			want: ProblemDetailsInvalidParam{Name: "id", In: "path"},
+		},
+		"missing query parameter": {
+			r:    getPet("/pets/1", "r1"),
+			want: ProblemDetailsInvalidParam{Name: "limit", In: "query"},
+		},
+		"missing header": {
+			r:    getPet("/pets/1?limit=1"),
+			want: ProblemDetailsInvalidParam{Name: "X-Request-Id", In: "header"},
+		},
+		"repeated header": {
+			r:    getPet("/pets/1?limit=1", "r1", "r2"),
+			want: ProblemDetailsInvalidParam{Name: "X-Request-Id", In: "header"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			problem := do(tc.r)
+			require.Len(t, problem.InvalidParams, 1)
+			assert.Equal(t, tc.want.Name, problem.InvalidParams[0].Name)
+			assert.Equal(t, tc.want.In, problem.InvalidParams[0].In)
+			assert.NotEmpty(t, problem.InvalidParams[0].Reason)
+		})
+	}
+
+	t.Run("invalid body", func(t *testing.T) {
+		problem := do(httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader("{")))
+		assert.Empty(t, problem.InvalidParams)
+		assert.Contains(t, problem.Detail, "can't decode JSON body")
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, getPet("/pets/1?limit=1", "r1"))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// AsyncAPIConstant is a constant naming a channel or a message of an
+// AsyncAPI document.
+type AsyncAPIConstant struct {
+	// Name is the name of the constant.
+	Name string
+	// Value is the address of the channel, or the name of the message.
+	Value string
+	// PayloadType is the type of the payload of the message, if it has one.
+	PayloadType string
+}
+
+// AsyncAPIConstants are the constants generated for the channels and the
+// messages of an AsyncAPI document.
+type AsyncAPIConstants struct {
+	Channels []AsyncAPIConstant
+	Messages []AsyncAPIConstant
+}
+
+// GenerateAsyncAPIConstants generates the typed constants of the channels
+// and the messages of the AsyncAPI document the spec was loaded from, whose
+// models are types.
+func GenerateAsyncAPIConstants(t *template.Template, asyncAPI *util.AsyncAPI, types []TypeDefinition) (string, error) {
+	declared := map[string]string{}
+	for _, td := range types {
+		declared[td.TypeName] = fmt.Sprintf("the type of the schema %q", td.JsonName)
+	}
+	declare := func(name, what string) error {
+		if other, ok := declared[name]; ok {
+			return fmt.Errorf("the Go name %s of %s is also %s", name, what, other)
+		}
+		declared[name] = what
+		return nil
+	}
+
+	var constants AsyncAPIConstants
+	if len(asyncAPI.Channels) > 0 {
+		if err := declare("Channel", "the channel type"); err != nil {
+			return "", err
+		}
+	}
+	for _, channel := range asyncAPI.Channels {
+		constant := AsyncAPIConstant{Name: "Channel" + SchemaNameToTypeName(channel.Name), Value: channel.Address}
+		if err := declare(constant.Name, fmt.Sprintf("the channel %q", channel.Name)); err != nil {
+			return "", err
+		}
+		constants.Channels = append(constants.Channels, constant)
+	}
+
+	if len(asyncAPI.Messages) > 0 {
+		if err := declare("MessageName", "the message name type"); err != nil {
+			return "", err
+		}
+	}
+	for _, message := range asyncAPI.Messages {
+		constant := AsyncAPIConstant{Name: "Message" + SchemaNameToTypeName(message.Name), Value: message.MessageName}
+		if message.Payload != "" {
+			constant.PayloadType = SchemaNameToTypeName(message.Payload)
+		}
+		if err := declare(constant.Name, fmt.Sprintf("the message %q", message.Name)); err != nil {
+			return "", err
+		}
+		constants.Messages = append(constants.Messages, constant)
+	}
+
+	return GenerateTemplates([]string{"asyncapi.tmpl"}, t, constants)
+}
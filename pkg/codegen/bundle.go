@@ -0,0 +1,28 @@
+package codegen
+
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// BundleSpec transforms spec, in place, into the document which Generate
+// generates code from with opts, and which generate.embedded-spec embeds:
+// its operations are filtered by the tags and the operation IDs of the
+// output-options, its unused components are pruned, unless
+// output-options.skip-prune is set, and its external references are
+// relocated into its components, so that it's self-contained. The paths left
+// without operations by the filters are removed.
+func BundleSpec(spec *openapi3.T, opts Configuration) {
+	filterSpec(spec, opts, util.IsModelsOnlySpec(spec))
+	if spec.Paths != nil {
+		for path, pathItem := range spec.Paths.Map() {
+			if len(pathItem.Operations()) == 0 {
+				spec.Paths.Delete(path)
+			}
+		}
+	}
+	spec.InternalizeRefs(context.Background(), nil)
+}
@@ -0,0 +1,375 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+)
+
+// CloneEqualMethods holds the bodies of the Clone and Equal methods generated
+// for a single type.
+type CloneEqualMethods struct {
+	TypeName string
+	// Alias is set for type aliases, which can't have methods, so get
+	// `Clone<Type>` and `Equal<Type>` functions with the same bodies.
+	Alias bool
+	// Clone is the body of `func (t T) Clone() T`.
+	Clone string
+	// Equal is the body of `func (t T) Equal(other T) bool`.
+	Equal string
+}
+
+// cloneEqualTypes resolves the Go types used by generated types, to work out
+// how to deep copy and compare values of them.
+type cloneEqualTypes struct {
+	// decls holds the parsed declaration of each generated type.
+	decls map[string]ast.Expr
+	// aliases holds the generated types which are type aliases.
+	aliases map[string]bool
+	// methods holds the generated types which have Clone and Equal methods.
+	methods map[string]bool
+}
+
+// GenerateCloneEqualMethods generates `Clone()` and `Equal(other)` methods for
+// every generated struct, slice, map and union type, and `Clone<Type>` and
+// `Equal<Type>` functions for the type aliases of structs, slices and maps.
+func GenerateCloneEqualMethods(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	types := cloneEqualTypes{
+		decls:   map[string]ast.Expr{},
+		aliases: map[string]bool{},
+		methods: map[string]bool{},
+	}
+	var names []string
+	for _, td := range typeDefs {
+		if _, seen := types.decls[td.TypeName]; seen {
+			continue
+		}
+		expr, err := parser.ParseExpr(td.Schema.TypeDecl())
+		if err != nil {
+			return "", fmt.Errorf("error parsing type declaration of %s: %w", td.TypeName, err)
+		}
+		types.decls[td.TypeName] = expr
+		types.aliases[td.TypeName] = td.IsAlias()
+		names = append(names, td.TypeName)
+	}
+
+	// A defined type gets methods if its declaration is a struct, slice or
+	// map, or another type which has them. Repeat until nothing changes, as
+	// types may be declared in any order.
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if types.aliases[name] || types.methods[name] {
+				continue
+			}
+			if types.hasMethods(types.decls[name]) {
+				types.methods[name] = true
+				changed = true
+			}
+		}
+	}
+
+	var methods []CloneEqualMethods
+	for _, name := range names {
+		// Aliases of other types share their methods, if any, so only the
+		// aliases of struct, slice and map types need functions.
+		alias := types.aliases[name] && types.hasMethods(types.decls[name])
+		if _, isIdent := types.decls[name].(*ast.Ident); isIdent {
+			alias = false
+		}
+		if !types.methods[name] && !alias {
+			continue
+		}
+		m, err := types.methodsFor(name)
+		if err != nil {
+			return "", err
+		}
+		m.Alias = alias
+		methods = append(methods, m)
+	}
+	if len(methods) == 0 {
+		return "", nil
+	}
+
+	return GenerateTemplates([]string{"clone-equal.tmpl"}, t, methods)
+}
+
+// hasMethods returns whether a defined type declared as expr should get
+// Clone and Equal methods.
+func (ct cloneEqualTypes) hasMethods(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.StructType, *ast.MapType:
+		return true
+	case *ast.ArrayType:
+		return e.Len == nil
+	case *ast.Ident:
+		return ct.methods[e.Name]
+	}
+	return false
+}
+
+// methodsFor generates the Clone and Equal methods of the named type.
+func (ct cloneEqualTypes) methodsFor(name string) (CloneEqualMethods, error) {
+	m := CloneEqualMethods{TypeName: name}
+	switch e := ct.decls[name].(type) {
+	case *ast.StructType:
+		var clone, equal []string
+		for _, field := range e.Fields.List {
+			fieldType, err := typeString(field.Type)
+			if err != nil {
+				return m, err
+			}
+			for _, fieldName := range fieldNames(field) {
+				src := "t." + fieldName
+				if copied := ct.cloneCall(field.Type, src); copied != src {
+					clone = append(clone, fmt.Sprintf("c.%s = %s", fieldName, copied))
+				}
+				if fieldName == "union" && fieldType == "json.RawMessage" {
+					// Unions compare by their decoded JSON, not their encoding.
+					equal = append(equal, "equalJSON(t.union, other.union)")
+					continue
+				}
+				equal = append(equal, ct.equalCall(field.Type, src, "other."+fieldName))
+			}
+		}
+		if len(equal) == 0 {
+			equal = []string{"true"}
+		}
+		m.Clone = strings.Join(append(append([]string{"c := t"}, clone...), "return c"), "\n")
+		m.Equal = "return " + strings.Join(equal, " &&\n")
+	case *ast.Ident:
+		// Defined types don't inherit methods, so convert to the type which
+		// has them.
+		m.Clone = fmt.Sprintf("return %s(%s(t).Clone())", name, e.Name)
+		m.Equal = fmt.Sprintf("return %s(t).Equal(%s(other))", e.Name, e.Name)
+	default:
+		m.Clone = "return " + ct.cloneCall(e, "t")
+		m.Equal = "return " + ct.equalCall(e, "t", "other")
+	}
+	return m, nil
+}
+
+// cloneCall returns an expression which deep copies src, of type expr. It
+// returns src if a plain copy is enough.
+func (ct cloneEqualTypes) cloneCall(expr ast.Expr, src string) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ct.methods[e.Name] {
+			return src + ".Clone()"
+		}
+		if decl, ok := ct.decls[e.Name]; ok && ct.aliases[e.Name] {
+			return ct.cloneCall(decl, src)
+		}
+		if e.Name == "any" {
+			return "cloneAny(" + src + ")"
+		}
+	case *ast.InterfaceType:
+		return "cloneAny(" + src + ")"
+	case *ast.StarExpr:
+		return fmt.Sprintf("clonePointer(%s, %s)", src, ct.cloneFunc(e.X))
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return fmt.Sprintf("cloneSlice(%s, %s)", src, ct.cloneFunc(e.Elt))
+		}
+	case *ast.MapType:
+		return fmt.Sprintf("cloneMap(%s, %s)", src, ct.cloneFunc(e.Value))
+	case *ast.SelectorExpr:
+		if selectorString(e) == "json.RawMessage" {
+			return fmt.Sprintf("cloneSlice(%s, nil)", src)
+		}
+	case *ast.IndexExpr:
+		switch genericTypeName(e) {
+		case "nullable.Nullable":
+			return fmt.Sprintf("cloneMap(%s, %s)", src, ct.cloneFunc(e.Index))
+		case "Optional":
+			if clone := ct.cloneFunc(e.Index); clone != "nil" {
+				return fmt.Sprintf("NewOptionalFromPtr(clonePointer(%s.Ptr(), %s))", src, clone)
+			}
+		}
+	case *ast.StructType:
+		if clone := ct.cloneFunc(e); clone != "nil" {
+			return clone + "(" + src + ")"
+		}
+	}
+	return src
+}
+
+// cloneFunc returns a function which deep copies values of type expr, or
+// `nil` if a plain copy is enough.
+func (ct cloneEqualTypes) cloneFunc(expr ast.Expr) string {
+	if e, ok := expr.(*ast.StructType); ok {
+		// Copy the fields of anonymous structs one by one.
+		var clone []string
+		for _, field := range e.Fields.List {
+			for _, fieldName := range fieldNames(field) {
+				v := "v." + fieldName
+				if copied := ct.cloneCall(field.Type, v); copied != v {
+					clone = append(clone, fmt.Sprintf("c.%s = %s", fieldName, copied))
+				}
+			}
+		}
+		typ, err := typeString(e)
+		if len(clone) == 0 || err != nil {
+			return "nil"
+		}
+		return fmt.Sprintf("func(v %s) %s {\nc := v\n%s\nreturn c\n}", typ, typ, strings.Join(clone, "\n"))
+	}
+	if e, ok := expr.(*ast.Ident); ok && ct.methods[e.Name] {
+		return e.Name + ".Clone"
+	}
+	if copied := ct.cloneCall(expr, "v"); copied != "v" {
+		typ, err := typeString(expr)
+		if err != nil {
+			return "nil"
+		}
+		return fmt.Sprintf("func(v %s) %s { return %s }", typ, typ, copied)
+	}
+	return "nil"
+}
+
+// equalCall returns an expression which reports whether a and b, of type
+// expr, are equal.
+func (ct cloneEqualTypes) equalCall(expr ast.Expr, a, b string) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ct.methods[e.Name] {
+			return fmt.Sprintf("%s.Equal(%s)", a, b)
+		}
+		if decl, ok := ct.decls[e.Name]; ok {
+			// Other defined types are enums, or definitions of scalars.
+			if _, isIdent := decl.(*ast.Ident); isIdent || ct.aliases[e.Name] {
+				return ct.equalCall(decl, a, b)
+			}
+			return fmt.Sprintf("reflect.DeepEqual(%s, %s)", a, b)
+		}
+		switch e.Name {
+		case "any":
+			return fmt.Sprintf("equalAny(%s, %s)", a, b)
+		case "string", "bool", "byte", "rune", "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return fmt.Sprintf("%s == %s", a, b)
+		}
+	case *ast.InterfaceType:
+		return fmt.Sprintf("equalAny(%s, %s)", a, b)
+	case *ast.StarExpr:
+		return fmt.Sprintf("equalPointer(%s, %s, %s)", a, b, ct.equalFunc(e.X))
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return fmt.Sprintf("equalSlice(%s, %s, %s)", a, b, ct.equalFunc(e.Elt))
+		}
+	case *ast.MapType:
+		return fmt.Sprintf("equalMap(%s, %s, %s)", a, b, ct.equalFunc(e.Value))
+	case *ast.SelectorExpr:
+		switch selectorString(e) {
+		case "json.RawMessage":
+			return fmt.Sprintf("equalJSON(%s, %s)", a, b)
+		case "time.Time":
+			return fmt.Sprintf("%s.Equal(%s)", a, b)
+		case "openapi_types.UUID", "openapi_types.Email", "openapi_types.Date",
+			"types.TimeOfDay", "types.StringInt64", "types.StringUint64":
+			return fmt.Sprintf("%s == %s", a, b)
+		case "types.Decimal", "types.DecimalNumber":
+			// Decimals keep the digits they were parsed from, so compare
+			// their values.
+			return fmt.Sprintf("%s.Equal(%s)", a, b)
+		}
+	case *ast.IndexExpr:
+		switch genericTypeName(e) {
+		case "nullable.Nullable":
+			return fmt.Sprintf("equalMap(%s, %s, %s)", a, b, ct.equalFunc(e.Index))
+		case "Optional":
+			return fmt.Sprintf("equalPointer(%s.Ptr(), %s.Ptr(), %s)", a, b, ct.equalFunc(e.Index))
+		}
+	case *ast.StructType:
+		var equal []string
+		for _, field := range e.Fields.List {
+			for _, fieldName := range fieldNames(field) {
+				equal = append(equal, ct.equalCall(field.Type, a+"."+fieldName, b+"."+fieldName))
+			}
+		}
+		switch len(equal) {
+		case 0:
+			return "true"
+		case 1:
+			return equal[0]
+		}
+		return "(" + strings.Join(equal, " && ") + ")"
+	}
+	// We don't know whether values of other types, such as those from
+	// `x-go-type`, are comparable.
+	return fmt.Sprintf("reflect.DeepEqual(%s, %s)", a, b)
+}
+
+// equalFunc returns a function which reports whether two values of type expr
+// are equal.
+func (ct cloneEqualTypes) equalFunc(expr ast.Expr) string {
+	if e, ok := expr.(*ast.Ident); ok && ct.methods[e.Name] {
+		return e.Name + ".Equal"
+	}
+	typ, err := typeString(expr)
+	if err != nil {
+		return "nil"
+	}
+	equal := ct.equalCall(expr, "a", "b")
+	if equal == "a == b" {
+		return fmt.Sprintf("equalValue[%s]", typ)
+	}
+	return fmt.Sprintf("func(a, b %s) bool { return %s }", typ, equal)
+}
+
+// fieldNames returns the names of a struct field, which is the type name
+// for an embedded field.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return []string{e.Name}
+		case *ast.SelectorExpr:
+			return []string{e.Sel.Name}
+		}
+		return nil
+	}
+	names := make([]string, len(field.Names))
+	for i, n := range field.Names {
+		names[i] = n.Name
+	}
+	return names
+}
+
+// selectorString returns a qualified type name, such as `time.Time`.
+func selectorString(e *ast.SelectorExpr) string {
+	if pkg, ok := e.X.(*ast.Ident); ok {
+		return pkg.Name + "." + e.Sel.Name
+	}
+	return ""
+}
+
+// genericTypeName returns the name of an instantiated generic type, such as
+// `nullable.Nullable` for `nullable.Nullable[string]`.
+func genericTypeName(e *ast.IndexExpr) string {
+	switch x := e.X.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return selectorString(x)
+	}
+	return ""
+}
+
+// typeString formats a parsed type expression back into Go source.
+func typeString(expr ast.Expr) (string, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return "", fmt.Errorf("error formatting type: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -64,6 +64,12 @@ var globalState struct {
 	initialismsMap map[string]string
 	// typeMapping is the merged type mapping (defaults + user overrides).
 	typeMapping TypeMapping
+	// typeMappingOverrides maps the schemas matched by a path-scoped
+	// type-mapping override to the Go type they're generated as.
+	typeMappingOverrides map[*openapi3.SchemaRef]SimpleTypeSpec
+	// typeMappingImports collects the imports of every type mapping used
+	// while generating, keyed by import path.
+	typeMappingImports map[string]goImport
 	// resolvedNames maps schema path strings (e.g. "components/schemas/Pet")
 	// to their resolved Go type names, assigned by the multi-pass name resolver.
 	resolvedNames map[string]string
@@ -86,6 +92,19 @@ var globalState struct {
 	// on strict RequestObject structs; identical to the schema generator
 	// except the legacy yaml-tags flag does not apply.
 	paramFieldTagGenerator *structTagGenerator
+	// fingerprint is the header line recording the fingerprint of the
+	// generated code, with output-options.fingerprint.
+	fingerprint string
+	// diagnostics are the problems of the spec found while generating code
+	// from it, which GenerateWithDiagnostics returns.
+	diagnostics []Diagnostic
+	// renamedTypes maps the Go type names which the multi-pass name resolver
+	// changed from their candidates to the names they were resolved from.
+	renamedTypes map[string]*ResolvedName
+	// emittedTypes and emittedOperations are the types and the operations
+	// code was generated for, which the source map locates in the spec.
+	emittedTypes      []TypeDefinition
+	emittedOperations []OperationDefinition
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -163,6 +182,24 @@ func constructImportMapping(importMapping map[string]string) importMap {
 	return result
 }
 
+// setNameNormalizer sets the name normalizer, and the initialisms, which
+// Go names are derived with, from opts.
+func setNameNormalizer(opts Configuration) error {
+	nameNormalizerFunction := NameNormalizerFunction(opts.OutputOptions.NameNormalizer)
+	nameNormalizer = NameNormalizers[nameNormalizerFunction]
+	if nameNormalizer == nil {
+		return fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
+			opts.OutputOptions.NameNormalizer, NameNormalizers.Options())
+	}
+
+	if nameNormalizerFunction != NameNormalizerFunctionToCamelCaseWithInitialisms && len(opts.OutputOptions.AdditionalInitialisms) > 0 {
+		return fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
+	}
+
+	globalState.initialismsMap = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
+	return nil
+}
+
 // Generate uses the Go templating engine to generate all of our server wrappers from
 // the descriptions we've built up above from the schema objects.
 // opts defines
@@ -170,13 +207,24 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// This is global state
 	globalState.options = opts
 	globalState.spec = spec
+	globalState.diagnostics = nil
+	globalState.emittedTypes = nil
+	globalState.emittedOperations = nil
 	globalState.is31 = spec.IsOpenAPI31OrLater()
 	globalState.importMapping = constructImportMapping(opts.ImportMapping)
+	globalState.typeMapping = DefaultTypeMapping
+	if opts.OutputOptions.FormatTypes {
+		globalState.typeMapping = globalState.typeMapping.Merge(FormatTypeMapping)
+	}
 	if opts.OutputOptions.TypeMapping != nil {
-		globalState.typeMapping = DefaultTypeMapping.Merge(*opts.OutputOptions.TypeMapping)
-	} else {
-		globalState.typeMapping = DefaultTypeMapping
+		globalState.typeMapping = globalState.typeMapping.Merge(*opts.OutputOptions.TypeMapping)
+	}
+	globalState.typeMappingImports = map[string]goImport{}
+	typeMappingOverrides, err := matchTypeMappingOverrides(spec, globalState.typeMapping.Overrides)
+	if err != nil {
+		return "", fmt.Errorf("error in output-options.type-mapping: %w", err)
 	}
+	globalState.typeMappingOverrides = typeMappingOverrides
 
 	// Build the struct tag generators eagerly so invalid user templates in
 	// output-options.struct-tags are reported as errors instead of being
@@ -194,17 +242,42 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	}
 	globalState.paramFieldTagGenerator = paramTagGen
 
-	filterOperationsByTag(spec, opts)
-	filterOperationsByOperationID(spec, opts)
-	if !opts.OutputOptions.SkipPrune {
-		pruneUnusedComponents(spec)
+	// The fingerprint covers the spec as it was loaded, before filtering.
+	globalState.fingerprint = ""
+	if opts.OutputOptions.Fingerprint {
+		fingerprint, err := generateFingerprint(spec, opts)
+		if err != nil {
+			return "", fmt.Errorf("error generating fingerprint: %w", err)
+		}
+		globalState.fingerprint = fingerprint.String()
+	}
+
+	// JSON Schema and AsyncAPI documents only define models, for all of
+	// their schemas, which aren't referenced from any operation.
+	asyncAPI, err := util.AsyncAPISpec(spec)
+	if err != nil {
+		return "", err
+	}
+	var modelsOnly string
+	switch {
+	case util.IsJSONSchemaSpec(spec):
+		modelsOnly = "JSON Schema"
+	case asyncAPI != nil:
+		modelsOnly = "AsyncAPI"
+	}
+	if modelsOnly != "" && opts.Generate != (GenerateOptions{Models: true}) {
+		return "", fmt.Errorf("only models can be generated from %s documents", modelsOnly)
 	}
 
+	filterSpec(spec, opts, modelsOnly != "")
+
 	// Reject spec values that cannot be represented in the generated Go source
 	// (names, media types, enum values, extension hints containing quotes,
 	// backticks, or control characters). Run after filtering/pruning so only
 	// values that will actually be emitted are considered.
-	if err := ValidateSpec(spec); err != nil {
+	diagnostics := validateSpec(spec)
+	globalState.diagnostics = append(globalState.diagnostics, diagnostics...)
+	if err := diagnosticsError(diagnostics); err != nil {
 		return "", err
 	}
 	if opts.Generate.StdHTTPServer {
@@ -222,19 +295,21 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		globalState.options.OutputOptions.ClientTypeName = defaultClientTypeName
 	}
 
-	nameNormalizerFunction := NameNormalizerFunction(opts.OutputOptions.NameNormalizer)
-	nameNormalizer = NameNormalizers[nameNormalizerFunction]
-	if nameNormalizer == nil {
-		return "", fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
-			opts.OutputOptions.NameNormalizer, NameNormalizers.Options())
+	if serveSpec := globalState.options.OutputOptions.ServeSpec; serveSpec != nil {
+		defaulted := *serveSpec
+		if defaulted.JSONPath == "" {
+			defaulted.JSONPath = defaultServeSpecJSONPath
+		}
+		if defaulted.YAMLPath == "" {
+			defaulted.YAMLPath = defaultServeSpecYAMLPath
+		}
+		globalState.options.OutputOptions.ServeSpec = &defaulted
 	}
 
-	if nameNormalizerFunction != NameNormalizerFunctionToCamelCaseWithInitialisms && len(opts.OutputOptions.AdditionalInitialisms) > 0 {
-		return "", fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
+	if err := setNameNormalizer(opts); err != nil {
+		return "", err
 	}
 
-	globalState.initialismsMap = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
-
 	// Compile streaming-content-type patterns (defaults merged with user-supplied).
 	// Validate() already caught syntax errors, but surface any regression here too.
 	streamingRegexes, err := compileStreamingContentTypes(opts.OutputOptions.StreamingContentTypes)
@@ -255,7 +330,15 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// Only enabled when resolve-type-name-collisions is set.
 	if opts.OutputOptions.ResolveTypeNameCollisions {
 		gathered := GatherSchemas(spec, opts)
-		globalState.resolvedNames = ResolveNames(gathered)
+		resolved := resolveNames(gathered)
+		globalState.resolvedNames = make(map[string]string, len(resolved))
+		globalState.renamedTypes = make(map[string]*ResolvedName)
+		for _, name := range resolved {
+			globalState.resolvedNames[name.Schema.Path.String()] = name.GoName
+			if name.GoName != name.Candidate {
+				globalState.renamedTypes[name.GoName] = name
+			}
+		}
 		// Build a separate operationID -> wrapper name lookup for genResponseTypeName.
 		// Keys must use the normalized operationID (via nameNormalizer) because
 		// OperationDefinition.OperationId is normalized before templates run.
@@ -270,6 +353,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	} else {
 		globalState.resolvedNames = nil
+		globalState.renamedTypes = nil
 		globalState.resolvedClientWrapperNames = nil
 	}
 
@@ -334,6 +418,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		return "", fmt.Errorf("error creating callback operation definitions: %w", err)
 	}
 	allOps := append(append(append([]OperationDefinition{}, ops...), webhookOps...), callbackOps...)
+	globalState.emittedOperations = allOps
 
 	xGoTypeImports, err := OperationImports(allOps)
 	if err != nil {
@@ -347,6 +432,9 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	// compatibility (changing it would alter the symbols emitted by
 	// `models: true`-alone configs and break downstream code in the wild).
 	var typeDefinitions, constantDefinitions string
+	// constructorTypes are the types whose constructors are generated, once
+	// the rest of the code is.
+	var constructorTypes []TypeDefinition
 	if opts.Generate.Models {
 		componentTypes, err := collectComponentTypes(t, spec, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
@@ -372,6 +460,13 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("error generating constants: %w", err)
 		}
+		if asyncAPI != nil {
+			asyncAPIConstants, err := GenerateAsyncAPIConstants(t, asyncAPI, componentTypes)
+			if err != nil {
+				return "", fmt.Errorf("error generating AsyncAPI constants: %w", err)
+			}
+			constantDefinitions += asyncAPIConstants
+		}
 
 		imprts, err := GetTypeDefinitionsImports(spec, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
@@ -383,6 +478,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		// marshalers) scans the union of all declared types so methods are
 		// emitted for inline types living inside operations too.
 		allEmitted := slices.Concat(componentTypes, opTypes)
+		globalState.emittedTypes = append(globalState.emittedTypes, allEmitted...)
 		enumsOut, allOfOut, unionOut, unionAndAdditionalOut, err := renderBoilerplate(t, allEmitted)
 		if err != nil {
 			return "", err
@@ -390,6 +486,26 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		// Preserve historical concatenation order:
 		// enums, component decls, op decls, allOf, union, union+additional.
 		typeDefinitions = strings.Join([]string{enumsOut, componentDecls, opDecls, allOfOut, unionOut, unionAndAdditionalOut}, "")
+
+		if opts.OutputOptions.CloneAndEqual {
+			cloneEqualOut, err := GenerateCloneEqualMethods(t, allEmitted)
+			if err != nil {
+				return "", fmt.Errorf("error generating Clone and Equal methods: %w", err)
+			}
+			typeDefinitions += cloneEqualOut
+		}
+
+		if opts.OutputOptions.Constructors {
+			constructorTypes = allEmitted
+		}
+
+		if opts.OutputOptions.OptionalType {
+			optionalOut, err := GenerateTemplates([]string{"optional.tmpl"}, t, nil)
+			if err != nil {
+				return "", fmt.Errorf("error generating Optional type: %w", err)
+			}
+			typeDefinitions += optionalOut
+		}
 	}
 
 	var serverURLsDefinitions string
@@ -404,6 +520,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("error generating Go types for server URL variables: %w", err)
 		}
+		globalState.emittedTypes = append(globalState.emittedTypes, serverURLEnumTypes...)
 		serverURLEnumTypeDecls, err := GenerateTypes(t, serverURLEnumTypes)
 		if err != nil {
 			return "", fmt.Errorf("error generating type declarations for server URL variables: %w", err)
@@ -493,6 +610,38 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	}
 
+	var problemDetailsOut string
+	if opts.OutputOptions.ProblemDetails {
+		problemDetailsOut, err = GenerateProblemDetails(t, opts)
+		if err != nil {
+			return "", fmt.Errorf("error generating problem details: %w", err)
+		}
+	}
+
+	var corsOut string
+	if opts.OutputOptions.CORS {
+		corsOut, err = GenerateCORS(t)
+		if err != nil {
+			return "", fmt.Errorf("error generating CORS options: %w", err)
+		}
+	}
+
+	var serveSpecOut string
+	if opts.OutputOptions.ServeSpec != nil {
+		serveSpecOut, err = GenerateServeSpec(t)
+		if err != nil {
+			return "", fmt.Errorf("error generating spec handlers: %w", err)
+		}
+	}
+
+	var operationRegistryOut string
+	if opts.OutputOptions.OperationRegistry {
+		operationRegistryOut, err = GenerateOperationRegistry(t, ops)
+		if err != nil {
+			return "", fmt.Errorf("error generating operation registry: %w", err)
+		}
+	}
+
 	var strictServerOut string
 	if opts.Generate.Strict {
 		var responses []ResponseDefinition
@@ -732,6 +881,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	w := bufio.NewWriter(&buf)
 
 	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
+	externalImports = append(externalImports, importMap(globalState.typeMappingImports).GoImports()...)
 	importsOut, err := GenerateImports(
 		t,
 		externalImports,
@@ -963,6 +1113,26 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	}
 
+	_, err = w.WriteString(problemDetailsOut)
+	if err != nil {
+		return "", fmt.Errorf("error writing problem details: %w", err)
+	}
+
+	_, err = w.WriteString(operationRegistryOut)
+	if err != nil {
+		return "", fmt.Errorf("error writing operation registry: %w", err)
+	}
+
+	_, err = w.WriteString(corsOut)
+	if err != nil {
+		return "", fmt.Errorf("error writing CORS options: %w", err)
+	}
+
+	_, err = w.WriteString(serveSpecOut)
+	if err != nil {
+		return "", fmt.Errorf("error writing spec handlers: %w", err)
+	}
+
 	if opts.Generate.EmbeddedSpec {
 		_, err = w.WriteString(inlinedSpec)
 		if err != nil {
@@ -970,6 +1140,24 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 		}
 	}
 
+	// Constructors are generated last, so that they don't collide with the
+	// functions which the other generators declare, such as the client's
+	// `New<OperationId>Request`.
+	if constructorTypes != nil {
+		err = w.Flush()
+		if err != nil {
+			return "", fmt.Errorf("error flushing output buffer: %w", err)
+		}
+		constructorsOut, err := GenerateConstructors(t, constructorTypes, declaredNames(buf.String()))
+		if err != nil {
+			return "", fmt.Errorf("error generating constructors: %w", err)
+		}
+		_, err = w.WriteString(constructorsOut)
+		if err != nil {
+			return "", fmt.Errorf("error writing constructors: %w", err)
+		}
+	}
+
 	err = w.Flush()
 	if err != nil {
 		return "", fmt.Errorf("error flushing output buffer: %w", err)
@@ -1388,6 +1576,17 @@ func GenerateTypesForSecuritySchemes(t *template.Template, schemes map[string]*o
 	return types, nil
 }
 
+// typeNameCollisionError is the error of types whose names collide, which
+// GenerateWithDiagnostics reports at the schemas of the types.
+type typeNameCollisionError struct {
+	typeName string
+}
+
+func (e typeNameCollisionError) Error() string {
+	return fmt.Sprintf("duplicate typename '%s' detected, can't auto-rename, "+
+		"please use x-go-name to specify your own name for one of them", e.typeName)
+}
+
 // GenerateTypes passes a bunch of types to the template engine, and buffers
 // its output into a string.
 func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error) {
@@ -1402,8 +1601,7 @@ func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error)
 			if TypeDefinitionsEquivalent(prevType, typ) {
 				continue
 			}
-			return "", fmt.Errorf("duplicate typename '%s' detected, can't auto-rename, "+
-				"please use x-go-name to specify your own name for one of them", typ.TypeName)
+			return "", typeNameCollisionError{typeName: typ.TypeName}
 		}
 
 		m[typ.TypeName] = typ
@@ -1619,23 +1817,7 @@ func enumsConflict(a, b EnumDefinition) bool {
 
 // GenerateImports generates our import statements and package definition.
 func GenerateImports(t *template.Template, externalImports []string, packageName string, versionOverride *string) (string, error) {
-	// Read build version for incorporating into generated files
-	// Unit tests have ok=false, so we'll just use "unknown" for the
-	// version if we can't read this.
-
-	modulePath := "unknown module path"
-	moduleVersion := "unknown version"
-	if bi, ok := debug.ReadBuildInfo(); ok {
-		if bi.Main.Path != "" {
-			modulePath = bi.Main.Path
-		}
-		if bi.Main.Version != "" {
-			moduleVersion = bi.Main.Version
-		}
-		if versionOverride != nil {
-			moduleVersion = *versionOverride
-		}
-	}
+	modulePath, moduleVersion := generatorVersion(versionOverride)
 
 	context := struct {
 		ExternalImports   []string
@@ -1644,6 +1826,7 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		Version           string
 		AdditionalImports []AdditionalImport
 		RouterImports     []AdditionalImport
+		Fingerprint       string
 	}{
 		ExternalImports:   externalImports,
 		PackageName:       packageName,
@@ -1651,11 +1834,35 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		Version:           moduleVersion,
 		AdditionalImports: globalState.options.AdditionalImports,
 		RouterImports:     globalState.options.Generate.RouterImports(),
+		Fingerprint:       globalState.fingerprint,
 	}
 
 	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
 }
 
+// generatorVersion returns the module path and the version of the generator,
+// which versionOverride overrides if it isn't nil.
+func generatorVersion(versionOverride *string) (modulePath, moduleVersion string) {
+	// Read build version for incorporating into generated files
+	// Unit tests have ok=false, so we'll just use "unknown" for the
+	// version if we can't read this.
+
+	modulePath = "unknown module path"
+	moduleVersion = "unknown version"
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if bi.Main.Path != "" {
+			modulePath = bi.Main.Path
+		}
+		if bi.Main.Version != "" {
+			moduleVersion = bi.Main.Version
+		}
+		if versionOverride != nil {
+			moduleVersion = *versionOverride
+		}
+	}
+	return modulePath, moduleVersion
+}
+
 // GenerateAdditionalPropertyBoilerplate generates all the glue code which provides
 // the API for interacting with additional properties and JSON-ification
 func GenerateAdditionalPropertyBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
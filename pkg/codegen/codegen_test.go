@@ -838,3 +838,809 @@ paths:
 
 //go:embed test_spec.yaml
 var testOpenAPIDefinition string
+
+func TestOptionalType(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+		OutputOptions: OutputOptions{
+			OptionalType: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/optional-type.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	// Optional fields are wrapped, and rely on `omitzero` to be omitted
+	assert.Contains(t, code, "Tag      Optional[string] `json:\"tag,omitzero\"`")
+	assert.Contains(t, code, "Age      Optional[int]    `json:\"age,omitzero\"`")
+	// Required, nullable and skip-optional-pointer fields are unaffected
+	assert.Contains(t, code, "Name     string           `json:\"name\"`")
+	assert.Contains(t, code, "Nickname *string          `json:\"nickname\"`")
+	assert.Contains(t, code, "Owner    string           `json:\"owner,omitempty\"`")
+	assert.Contains(t, code, "type Optional[T any] struct {")
+
+	// Optional parameters are wrapped on the Params struct
+	assert.Contains(t, code, "Limit    Optional[int32]  `form:\"limit\" json:\"limit,omitzero\"`")
+	assert.Contains(t, code, "Tags     []string         `form:\"tags\" json:\"tags\"`")
+
+	// The client only encodes parameters that were set
+	assert.Contains(t, code, "if params.Limit.IsSet() {")
+	assert.Contains(t, code, `runtime.StyleParamWithOptions("form", true, "limit", params.Limit.MustGet(),`)
+	assert.Contains(t, code, "if params.XTraceId.IsSet() {")
+
+	// The server binds into a pointer, then wraps the bound value
+	assert.Contains(t, code, "var limitValue *int32")
+	assert.Contains(t, code, "params.Limit = NewOptionalFromPtr(limitValue)")
+	assert.Contains(t, code, "params.XTraceId = NewOptional(XTraceId)")
+
+	// As with fields, nullable parameters keep their pointer, and are bound
+	// into it directly.
+	assert.Contains(t, code, "Cursor   *string          `form:\"cursor,omitempty\" json:\"cursor,omitempty\"`")
+	assert.Contains(t, code, "if params.Cursor != nil {")
+	assert.Contains(t, code, `runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor,`)
+
+	// Even when nullable fields use nullable.Nullable.
+	opts.OutputOptions.NullableType = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Cursor   *string          `form:\"cursor,omitempty\" json:\"cursor,omitempty\"`")
+	assert.Contains(t, code, "Nickname nullable.Nullable[string] `json:\"nickname,omitempty\"`")
+}
+
+func TestOptionalTypeDisabled(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+			Client:    true,
+			Models:    true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/optional-type.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "Optional[")
+	assert.Contains(t, code, "Limit    *int32   `form:\"limit,omitempty\" json:\"limit,omitempty\"`")
+}
+
+func TestCloneAndEqual(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:     true,
+			CloneAndEqual: true,
+			FormatTypes:   true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/clone-equal.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Structs copy and compare field by field.
+	assert.Contains(t, code, "func (t Pet) Clone() Pet {")
+	assert.Contains(t, code, "c.Owner = clonePointer(t.Owner, Owner.Clone)")
+	assert.Contains(t, code, "equalPointer(t.Born, other.Born, func(a, b time.Time) bool { return a.Equal(b) })")
+	assert.Contains(t, code, "equalAny(t.Extra, other.Extra)")
+	// Additional properties are deep copied.
+	assert.Contains(t, code, "c.AdditionalProperties = cloneMap(t.AdditionalProperties, func(v []int) []int { return cloneSlice(v, nil) })")
+	// Unions compare by their decoded JSON.
+	assert.Contains(t, code, "c.union = cloneSlice(t.union, nil)")
+	assert.Contains(t, code, "return equalJSON(t.union, other.union)")
+	// Defined maps get methods, but aliases and enums can't, so aliases of
+	// slices get functions instead.
+	assert.Contains(t, code, "func (t Scores) Equal(other Scores) bool {")
+	assert.NotContains(t, code, "func (t Pets) Clone()")
+	assert.Contains(t, code, "func ClonePets(t Pets) Pets {\n\treturn cloneSlice(t, Pet.Clone)")
+	assert.Contains(t, code, "func EqualPets(t, other Pets) bool {")
+	assert.NotContains(t, code, "func (t Status) Clone()")
+	assert.NotContains(t, code, "func CloneStatus(")
+	// Decimals compare by value.
+	assert.Contains(t, code, "equalPointer(t.Price, other.Price, func(a, b types.Decimal) bool { return a.Equal(b) })")
+
+	// The code must also be valid Go.
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	opts.OutputOptions.CloneAndEqual = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "Clone()")
+}
+
+func TestConstructors(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:    true,
+			Constructors: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/constructors.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Required properties, including those of required inline objects, are
+	// positional, but read-only ones are left out. `NewPet` is a schema, so
+	// the constructor of `Pet` falls back to `MakePet`.
+	assert.Contains(t, code, "func MakePet(name string, ownerName string, password string, opts ...PetOption) Pet {")
+	assert.Contains(t, code, "t.Owner.Name = ownerName")
+	assert.Contains(t, code, "t.Password = &password")
+	assert.NotContains(t, code, "t.Id =")
+	assert.Contains(t, code, "func NewNewPet(name string, opts ...NewPetOption) NewPet {")
+	// Optional properties are set with functional options.
+	assert.Contains(t, code, "func WithPetOwnerEmail(v string) PetOption {")
+	assert.Contains(t, code, "t.Vet = &v")
+	assert.Contains(t, code, "func WithVetN2fa(v bool) VetOption {")
+
+	// The code must also be valid Go.
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	opts.OutputOptions.OptionalType = true
+	opts.OutputOptions.NullableType = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "t.Tag = NewOptional(v)")
+	assert.Contains(t, code, "t.Nickname = nullable.NewNullableWithValue(v)")
+
+	// The client declares `NewReindexRequest`, so the constructor of the
+	// ReindexRequest schema falls back to `MakeReindexRequest`.
+	swagger, err = util.LoadSwagger("test_specs/serve-spec.yaml")
+	require.NoError(t, err)
+	opts = Configuration{
+		PackageName:   "api",
+		Generate:      GenerateOptions{Models: true, Client: true},
+		OutputOptions: OutputOptions{Constructors: true},
+	}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func NewReindexRequest(server string")
+	assert.Contains(t, code, "func MakeReindexRequest(")
+}
+
+func TestProblemDetails(t *testing.T) {
+	const spec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Problem details
+paths:
+  /pets/{id}:
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: ok
+`
+	loader := openapi3.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Models:        true,
+		},
+		OutputOptions: OutputOptions{
+			ProblemDetails: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func WriteProblemDetails(w http.ResponseWriter, r *http.Request, status int, err error) {")
+	assert.Contains(t, code, `&InvalidParamFormatError{ParamName: "id", ParamLocation: "path", Err: err}`)
+	assert.Contains(t, code, `&RequiredParamError{ParamName: "limit", ParamLocation: "query"}`)
+	assert.Contains(t, code, "WriteProblemDetails(w, r, http.StatusBadRequest, err)")
+	assert.Contains(t, code, "WriteProblemDetails(w, r, http.StatusInternalServerError, err)")
+	assert.Contains(t, code, `&RequestBodyError{Err: fmt.Errorf("can't decode JSON body: %w", err)}`)
+	assert.NotContains(t, code, "http.Error(")
+
+	// The other servers declare the typed parameter errors alongside the
+	// problem details, and write them directly.
+	opts.Generate.StdHTTPServer = false
+	opts.Generate.EchoServer = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type InvalidParamFormatError struct {")
+	assert.Contains(t, code, `return writeBadRequestProblem(ctx, &InvalidParamFormatError{ParamName: "id", ParamLocation: "path", Err: err})`)
+	assert.Contains(t, code, "return writeBadRequestProblem(ctx, &RequestBodyError{Err: err})")
+	assert.NotContains(t, code, "echo.NewHTTPError(")
+
+	// Problem details are only reported by servers.
+	opts.Generate.EchoServer = false
+	opts.Generate.Strict = false
+	assert.Error(t, opts.Validate())
+}
+
+func TestStrictErrorMapping(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Models:        true,
+		},
+		OutputOptions: OutputOptions{
+			StrictErrorMapping: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/strict-error-mapping.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type StrictErrorResponse interface {")
+	assert.Contains(t, code, "func MapStrictError[E error](statusCode int, body func(err E) any) StrictErrorMapping {")
+	assert.Contains(t, code, "func StrictErrorMiddleware(mappings ...StrictErrorMapping) StrictMiddlewareFunc {")
+	assert.Contains(t, code, "if mapped, ok := mapUpdatePetError(err); ok {")
+
+	// Exact status codes come before ranges, and ranges before `default`.
+	// The 429 response has headers, which an error can't set, so it's left
+	// out.
+	assert.Contains(t, code, `	case status == 404:
+		if body, ok := errResponse.ResponseBody().(Error); ok {
+			return UpdatePet404JSONResponse{NotFoundJSONResponse(body)}, true
+		}
+	case status == 409:
+		if body, ok := errResponse.ResponseBody().(Conflict); ok {
+			return UpdatePet409JSONResponse(body), true
+		}
+	case status >= 400 && status < 500:
+		if body, ok := errResponse.ResponseBody().(Error); ok {
+			return UpdatePet4XXJSONResponse{Body: body, StatusCode: status}, true
+		}
+	default:
+		if body, ok := errResponse.ResponseBody().(Error); ok {
+			return UpdatePetdefaultJSONResponse{Body: body, StatusCode: status}, true
+		}
+	}`)
+	assert.NotContains(t, code, "UpdatePet429JSONResponse(body)")
+
+	// ListPets declares no error responses.
+	assert.NotContains(t, code, "mapListPetsError")
+
+	// Error mapping is only done by strict servers.
+	opts.Generate.Strict = false
+	assert.Error(t, opts.Validate())
+}
+
+func TestStrictContentNegotiation(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Models:        true,
+		},
+		OutputOptions: OutputOptions{
+			StrictContentNegotiation: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/strict-content-negotiation.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func negotiateContentType(accept string, offers ...string) string {")
+
+	// GetPet's 200 response has several content types, so the content type
+	// is negotiated from all of its responses' content types.
+	assert.Contains(t, code, `request.NegotiatedContentType = negotiateContentType(r.Header.Get("Accept"), "application/json", "application/xml", "text/csv")`)
+	assert.Contains(t, code, "http.Error(w, ErrNotAcceptable.Error(), http.StatusNotAcceptable)")
+	assert.Contains(t, code, `type GetPet200MultiResponse struct {
+	JSON           *GetPet200JSONResponse
+	Applicationxml *GetPet200ApplicationxmlResponse
+	Textcsv        *GetPet200TextcsvResponse
+}`)
+	assert.Contains(t, code, "func (response GetPet200MultiResponse) VisitGetPetResponse(w http.ResponseWriter) error {")
+	assert.Contains(t, code, "if multi, ok := response.(negotiableResponse[GetPetResponseObject]); ok {")
+	assert.NotContains(t, code, "GetPet404MultiResponse")
+
+	// ListPets has a single content type, which isn't negotiated.
+	assert.NotContains(t, code, "ListPets200MultiResponse")
+	assert.NotContains(t, code, "negotiableResponse[ListPetsResponseObject]")
+
+	// Content negotiation is only done by strict servers.
+	opts.Generate.Strict = false
+	assert.Error(t, opts.Validate())
+}
+
+func TestOperationRegistry(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+		},
+		OutputOptions: OutputOptions{
+			OperationRegistry: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/operation-registry.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func OperationInfoFromContext(ctx context.Context) (OperationInfo, bool) {")
+
+	// The operation's own security overrides the spec's, and the raw
+	// operation ID is kept.
+	assert.Contains(t, code, `	{
+		Method:      "DELETE",
+		Path:        "/pets/{id}",
+		OperationID: "deletePet",
+		Tags:        []string{"pets", "admin"},
+		Security: []map[string][]string{
+			{"bearerAuth": []string{"pets:write"}},
+			{"apiKey": []string{}},
+		},
+		Deprecated: true,
+	},`)
+	assert.Contains(t, code, `	{
+		Method:      "GET",
+		Path:        "/pets/{id}",
+		OperationID: "get-pet",
+		Tags:        []string{"pets"},
+		Security: []map[string][]string{
+			{"bearerAuth": []string{}},
+		},
+	},`)
+
+	// Each wrapper puts its entry in the request's context.
+	assert.Contains(t, code, `func (siw *ServerInterfaceWrapper) GetPet(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(context.WithValue(r.Context(), operationInfoContextKey{}, Operations[2]))`)
+
+	// The registry is only generated for servers.
+	opts.Generate.StdHTTPServer = false
+	assert.Error(t, opts.Validate())
+}
+
+func TestRouteMiddlewares(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+		},
+		OutputOptions: OutputOptions{
+			RouteMiddlewares: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/route-middlewares.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "TagMiddlewares map[string][]MiddlewareFunc")
+	assert.Contains(t, code, "NamedMiddlewares map[string]MiddlewareFunc")
+	assert.Contains(t, code, "OperationMiddlewares map[string][]MiddlewareFunc")
+
+	// Each route's chain is composed when registering the handlers.
+	assert.Contains(t, code, `"deletePet": routeMiddlewares(options, "deletePet", []string{"pets", "admin"}, []string{}),`)
+	assert.Contains(t, code, `"upload":    routeMiddlewares(options, "upload", []string{}, []string{"bodyLimit"}),`)
+	assert.Contains(t, code, `middlewares := siw.middlewares("list-pets")`)
+
+	// The extension must list middleware names.
+	swagger.Paths.Find("/uploads").Post.Extensions["x-oapi-codegen-middlewares"] = "bodyLimit"
+	_, err = Generate(swagger, opts)
+	assert.Error(t, err)
+
+	// The routers of the other servers don't support it.
+	opts.Generate.StdHTTPServer = false
+	opts.Generate.EchoServer = true
+	assert.Error(t, opts.Validate())
+}
+
+func TestSplitServerInterfaceByTag(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			EchoServer: true,
+			Strict:     true,
+		},
+		OutputOptions: OutputOptions{
+			SplitServerInterfaceByTag: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/split-server-interface-by-tag.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Operations belong to their first tag, or their x-oapi-codegen-group,
+	// and operations without either to the default group.
+	assert.Contains(t, code, `type PetsServer interface {
+
+	// (GET /pets)
+	ListPets(ctx echo.Context) error
+
+	// (POST /pets)
+	AddPet(ctx echo.Context) error
+}`)
+	assert.Contains(t, code, `type AdminServer interface {
+
+	// (GET /store/inventory)
+	GetInventory(ctx echo.Context) error
+}`)
+	assert.Contains(t, code, "type DefaultServer interface {")
+	assert.Contains(t, code, "func NewServerInterface(adminServer AdminServer, defaultServer DefaultServer, petsServer PetsServer, storeServer StoreServer) ServerInterface {")
+
+	assert.Contains(t, code, `type StoreStrictServer interface {
+
+	// (POST /store/orders)
+	PlaceOrder(ctx context.Context, request PlaceOrderRequestObject) (PlaceOrderResponseObject, error)
+}`)
+	assert.Contains(t, code, "func NewStrictServerInterface(adminServer AdminStrictServer, defaultServer DefaultStrictServer, petsServer PetsStrictServer, storeServer StoreStrictServer) StrictServerInterface {")
+
+	// The group must be a string.
+	swagger.Paths.Find("/store/inventory").Get.Extensions["x-oapi-codegen-group"] = []any{"admin"}
+	_, err = Generate(swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestSplitClientByTag(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SplitClientByTag: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/split-client-by-tag.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Sub-clients are fields of the clients, sharing the client they're
+	// created by.
+	assert.Contains(t, code, `	// Pets holds the operations of the "pets" group.
+	Pets *PetsClient`)
+	assert.Contains(t, code, "client.Pets = &PetsClient{client: &client}")
+	assert.Contains(t, code, `func (c *PetsClient) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.client.AddPet(ctx, body, reqEditors...)
+}`)
+	assert.Contains(t, code, "c.Store = &StoreClientWithResponses{client: c}")
+	assert.Contains(t, code, `func (c *StoreClientWithResponses) GetOrderWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetOrderResponse, error) {
+	return c.client.GetOrderWithResponse(ctx, id, reqEditors...)
+}`)
+
+	// The flat API remains.
+	assert.Contains(t, code, "func (c *Client) FindPets(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+
+	// A group mustn't collide with the client's methods.
+	swagger.Paths.Find("/store/orders/{id}").Get.OperationID = "store"
+	_, err = Generate(swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestCORS(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+		},
+		OutputOptions: OutputOptions{
+			CORS: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/cors.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type CORSOptions struct {")
+	assert.Contains(t, code, "	CORS CORSOptions")
+
+	// The allowed methods are those of the path's operations, and the
+	// allowed headers are its header parameters, the headers of its security
+	// schemes, and the content type of its request bodies.
+	assert.Contains(t, code, `m.HandleFunc("OPTIONS "+options.BaseURL+"/pets", corsPreflightHandler(options.CORS, "GET, POST, OPTIONS", []string{"X-Request-ID", "X-API-Key", "Content-Type"}))`)
+	assert.Contains(t, code, `m.HandleFunc("OPTIONS "+options.BaseURL+"/pets/{id}", corsPreflightHandler(options.CORS, "DELETE, OPTIONS", []string{"Authorization"}))`)
+
+	// Paths declaring an OPTIONS operation are left to it.
+	assert.NotContains(t, code, `"OPTIONS "+options.BaseURL+"/preflight", corsPreflightHandler`)
+
+	// The responses to the operations get CORS headers too.
+	assert.Contains(t, code, `siw.CORS.setHeaders(r.Header.Get("Origin"), w.Header().Set, w.Header().Add)`)
+
+	// The other routers register the same preflight handlers.
+	opts.Generate.StdHTTPServer = false
+	opts.Generate.GinServer = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, `router.OPTIONS(options.BaseURL+"/pets/:id", corsPreflightHandler(options.CORS, "DELETE, OPTIONS", []string{"Authorization"}))`)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			ChiServer: true,
+		},
+		OutputOptions: OutputOptions{
+			MethodNotAllowed: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/method-not-allowed.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func methodNotAllowedHandler(allow string) http.HandlerFunc {")
+
+	// The methods of the API which no operation of a path has are answered
+	// with a 405 listing the path's methods, other than HEAD on the paths
+	// with a GET operation.
+	assert.Contains(t, code, `r.Delete(options.BaseURL+"/pets", methodNotAllowedHandler("GET, POST"))`)
+	assert.Contains(t, code, `r.Head(options.BaseURL+"/pets/{id}", methodNotAllowedHandler("DELETE"))`)
+	assert.NotContains(t, code, `r.Head(options.BaseURL+"/pets", methodNotAllowedHandler`)
+	assert.NotContains(t, code, `r.Delete(options.BaseURL+"/pets/{id}", methodNotAllowedHandler`)
+	assert.NotContains(t, code, `r.Put(`)
+	assert.NotContains(t, code, `r.Trace(`)
+
+	// With head-from-get, the GET operation of a path without a HEAD
+	// operation serves HEAD too.
+	opts.OutputOptions.HeadFromGet = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, `r.Head(options.BaseURL+"/pets", wrapper.ListPets)`)
+	assert.Contains(t, code, `r.Delete(options.BaseURL+"/pets", methodNotAllowedHandler("GET, HEAD, POST"))`)
+	assert.NotContains(t, code, `r.Head(options.BaseURL+"/status", wrapper.GetStatus)`)
+
+	// The other routers register the same handlers.
+	opts.Generate.ChiServer = false
+	opts.Generate.GorillaServer = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, `r.HandleFunc(options.BaseURL+"/pets/{id}", methodNotAllowedHandler("DELETE")).Methods(http.MethodGet, http.MethodHead, http.MethodPost)`)
+
+	// net/http's ServeMux answers 405s itself, so the std-http server
+	// registers none.
+	opts.Generate.GorillaServer = false
+	opts.Generate.StdHTTPServer = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "methodNotAllowedHandler")
+}
+
+func TestServeSpec(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Models:        true,
+			EmbeddedSpec:  true,
+		},
+		OutputOptions: OutputOptions{
+			ServeSpec: &ServeSpecOptions{},
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/serve-spec.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type SpecOptions struct {")
+	assert.Contains(t, code, "	Spec SpecOptions")
+	assert.Contains(t, code, "data, err := rawSpec()")
+	assert.NotContains(t, code, "var publicSwaggerSpec")
+
+	// The spec is served as JSON and as YAML under the BaseURL.
+	assert.Contains(t, code, "spec := newServedSpec(options.BaseURL, options.Spec)")
+	assert.Contains(t, code, `m.HandleFunc("GET "+options.BaseURL+"/openapi.json", specHandler(spec, false))`)
+	assert.Contains(t, code, `m.HandleFunc("GET "+options.BaseURL+"/openapi.yaml", specHandler(spec, true))`)
+
+	// The public view is embedded alongside the spec.
+	swagger, err = util.LoadSwagger("test_specs/serve-spec.yaml")
+	require.NoError(t, err)
+	opts.OutputOptions.ServeSpec = &ServeSpecOptions{JSONPath: "/spec.json", Public: true}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "var publicSwaggerSpec = []string{")
+	assert.Contains(t, code, "data, err := decodePublicSpec()")
+	assert.Contains(t, code, `m.HandleFunc("GET "+options.BaseURL+"/spec.json", specHandler(spec, false))`)
+
+	// The public view leaves out the operations marked `x-internal`, and the
+	// components which only they use.
+	swagger, err = util.LoadSwagger("test_specs/serve-spec.yaml")
+	require.NoError(t, err)
+	public, err := publicSpec(swagger)
+	require.NoError(t, err)
+	assert.NotNil(t, public.Paths.Value("/pets"))
+	assert.Nil(t, public.Paths.Value("/admin/reindex"))
+	assert.Contains(t, public.Components.Schemas, "Pet")
+	assert.NotContains(t, public.Components.Schemas, "ReindexRequest")
+
+	// The spec handlers need the embedded spec.
+	opts.Generate.EmbeddedSpec = false
+	assert.Error(t, opts.Validate())
+}
+
+func TestFingerprint(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/serve-spec.yaml")
+	require.NoError(t, err)
+
+	// Without output-options.fingerprint, there's no fingerprint.
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "//oapi-codegen:fingerprint")
+
+	opts.OutputOptions.Fingerprint = true
+	opts.FingerprintSource = &FingerprintSource{
+		SpecDigest: "0123",
+		Dir:        "..",
+		Config:     "cfg.yaml",
+		Spec:       "api/spec.yaml",
+	}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+
+	hash, err := InputsHash(opts, "0123")
+	require.NoError(t, err)
+	assert.Contains(t, code, `//oapi-codegen:fingerprint sha256:`+hash+` dir=".." config="cfg.yaml" spec="api/spec.yaml"`+"\n\n// Package api")
+
+	fingerprint, ok, err := ParseFingerprint([]byte(code))
+	require.NoError(t, err)
+	require.True(t, ok)
+	// The spec's digest is only recorded as part of the hash.
+	assert.Equal(t, Fingerprint{
+		FingerprintSource: FingerprintSource{Dir: "..", Config: "cfg.yaml", Spec: "api/spec.yaml"},
+		Hash:              hash,
+	}, fingerprint)
+
+	// The hash changes with the spec's digest and with the configuration.
+	otherHash, err := InputsHash(opts, "4567")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+	opts.Generate.Client = true
+	otherHash, err = InputsHash(opts, "0123")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	// Code without a fingerprint has none.
+	_, ok, err = ParseFingerprint([]byte("// Package api\npackage api\n"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSwagger2(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/swagger2.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type Pet struct {")
+	assert.Contains(t, code, "Name string  `json:\"name\"`")
+
+	// Query arrays keep Swagger 2.0's default collectionFormat, csv.
+	assert.Contains(t, code, `runtime.StyleParamWithOptions("form", false, "tags", *params.Tags`)
+}
+
+func TestJSONSchema(t *testing.T) {
+	opts := Configuration{
+		PackageName: "models",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		ImportMapping: map[string]string{
+			"address.schema.json": "example.com/address",
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/jsonschema/order.schema.json")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type Order struct {")
+	assert.Contains(t, code, "type Item struct {")
+	assert.Contains(t, code, "Items     *[]Item")
+	assert.Contains(t, code, "Reference *string")
+
+	// References to other documents are mapped to their packages.
+	assert.Contains(t, code, `externalRef0 "example.com/address"`)
+	assert.Contains(t, code, "ShipTo    *externalRef0.Address")
+
+	// The documents of a directory reference each other's schemas locally.
+	swagger, err = util.LoadSwagger("test_specs/jsonschema")
+	require.NoError(t, err)
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "type Address struct {")
+	assert.Contains(t, code, "ShipTo    *Address")
+
+	opts.Generate.Client = true
+	_, err = Generate(swagger, opts)
+	assert.EqualError(t, err, "only models can be generated from JSON Schema documents")
+}
+
+func TestAsyncAPI(t *testing.T) {
+	opts := Configuration{
+		PackageName: "events",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/asyncapi.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// Component schemas, and the payloads of component messages, are models.
+	assert.Contains(t, code, "type User struct {")
+	assert.Contains(t, code, "Role *Role  `json:\"role,omitempty\"`")
+	assert.Contains(t, code, "type UserSignedUpPayload = User")
+	assert.Contains(t, code, "type UserDeletedPayload struct {")
+	assert.Contains(t, code, "User   UserSignedUpPayload")
+	assert.Contains(t, code, "UserDeletedPayloadReason = \"banned\"")
+
+	// Channels and messages are typed constants.
+	assert.Contains(t, code, "type Channel string")
+	assert.Contains(t, code, "ChannelUsersignedup Channel = \"user/signedup\"")
+	assert.Contains(t, code, "type MessageName string")
+	assert.Contains(t, code, "MessageUserSignedUp MessageName = \"userSignedUp\"")
+	assert.Contains(t, code, "MessageUserDeleted MessageName = \"UserDeleted\"")
+
+	opts.Generate.Client = true
+	_, err = Generate(swagger, opts)
+	assert.EqualError(t, err, "only models can be generated from AsyncAPI documents")
+}
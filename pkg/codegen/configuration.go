@@ -40,6 +40,9 @@ type Configuration struct {
 	// NoVCSVersionOverride allows overriding the version of the application for cases where no Version Control System (VCS) is available when building, for instance when using a Nix derivation.
 	// See documentation for how to use it in examples/no-vcs-version-override/README.md
 	NoVCSVersionOverride *string `yaml:"-"`
+	// FingerprintSource describes the inputs of the generated code, for the
+	// fingerprint which output-options.fingerprint records. The CLI sets it.
+	FingerprintSource *FingerprintSource `yaml:"-"`
 }
 
 // Validate checks whether Configuration represent a valid configuration
@@ -80,6 +83,44 @@ func (o Configuration) Validate() error {
 	if nServers > 1 {
 		return errors.New("only one server type is supported at a time")
 	}
+	if o.OutputOptions.ProblemDetails && nServers == 0 {
+		return errors.New("output-options.problem-details requires a server to be generated")
+	}
+	if o.OutputOptions.OperationRegistry && nServers == 0 {
+		return errors.New("output-options.operation-registry requires a server to be generated")
+	}
+	if o.OutputOptions.CORS && nServers == 0 {
+		return errors.New("output-options.cors requires a server to be generated")
+	}
+	if o.OutputOptions.MethodNotAllowed && nServers == 0 {
+		return errors.New("output-options.method-not-allowed requires a server to be generated")
+	}
+	if o.OutputOptions.HeadFromGet && nServers == 0 {
+		return errors.New("output-options.head-from-get requires a server to be generated")
+	}
+	if o.OutputOptions.ServeSpec != nil {
+		if nServers == 0 {
+			return errors.New("output-options.serve-spec requires a server to be generated")
+		}
+		if !o.Generate.EmbeddedSpec {
+			return errors.New("output-options.serve-spec requires generate.embedded-spec")
+		}
+	}
+	if o.OutputOptions.SplitServerInterfaceByTag && nServers == 0 {
+		return errors.New("output-options.split-server-interface-by-tag requires a server to be generated")
+	}
+	if o.OutputOptions.SplitClientByTag && !o.Generate.Client {
+		return errors.New("output-options.split-client-by-tag requires generate.client")
+	}
+	if o.OutputOptions.RouteMiddlewares && !o.Generate.StdHTTPServer && !o.Generate.ChiServer && !o.Generate.GorillaServer {
+		return errors.New("output-options.route-middlewares requires generate.std-http-server, chi-server or gorilla-server")
+	}
+	if o.OutputOptions.StrictErrorMapping && !o.Generate.Strict {
+		return errors.New("output-options.strict-error-mapping requires generate.strict-server")
+	}
+	if o.OutputOptions.StrictContentNegotiation && !o.Generate.Strict {
+		return errors.New("output-options.strict-content-negotiation requires generate.strict-server")
+	}
 
 	var errs []error
 	if problems := o.Generate.Validate(); problems != nil {
@@ -449,6 +490,113 @@ type OutputOptions struct {
 	StreamingContentTypes []string `yaml:"streaming-content-types,omitempty"`
 	// Whether to generate nullable type for nullable fields
 	NullableType bool `yaml:"nullable-type,omitempty"`
+	// OptionalType generates optional (non-required) fields and parameters
+	// as a generated, generic `Optional[T]` instead of an optional pointer,
+	// so that an absent value can be distinguished from a zero value without
+	// a pointer. Fields use the `omitzero` JSON tag, which requires Go 1.24+.
+	// Nullable fields keep their pointer (or `nullable.Nullable[T]` with
+	// `nullable-type`), and fields that skip the optional pointer via
+	// `x-go-type-skip-optional-pointer` or `prefer-skip-optional-pointer` are
+	// not wrapped. The `Optional[T]` type is declared alongside the models, so
+	// this requires `generate.models: true` in (at least one config for) the
+	// same package.
+	OptionalType bool `yaml:"optional-type,omitempty"`
+	// CloneAndEqual generates `Clone()` and `Equal(other)` methods for every
+	// generated struct, slice, map and union type which isn't a type alias,
+	// and `Clone<Type>` and `Equal<Type>` functions for the type aliases of
+	// structs, slices and maps. `Clone` returns a deep copy, and `Equal`
+	// compares semantically: union and `json.RawMessage` values compare by
+	// their decoded JSON, rather than by their encoding, `time.Time` values
+	// with `Equal`, decimals by their value, and nil slices and maps are
+	// equal to empty ones.
+	CloneAndEqual bool `yaml:"clone-and-equal,omitempty"`
+	// Constructors generates a `New<Type>` constructor for every generated
+	// struct type with properties, so that required properties can't be
+	// left unset. Required properties are positional arguments, and the
+	// fields of required inline objects are too. Optional properties are set
+	// with `With<Type><Field>` functional options. Read-only properties are
+	// set by the server, so constructors leave them out. If `New<Type>` is
+	// already declared, the constructor is called `Make<Type>` instead.
+	Constructors bool `yaml:"constructors,omitempty"`
+	// ProblemDetails makes the generated servers report request errors as
+	// RFC 9457 `application/problem+json` responses, rather than plain
+	// text. Parameter errors are reported with the typed
+	// `InvalidParamFormatError`, `RequiredParamError`, `UnmarshalingParamError`
+	// etc, which carry the name and location of the parameter, and strict
+	// servers report errors decoding the request body as a
+	// `RequestBodyError`. Custom error handlers configured on the server
+	// still take precedence.
+	ProblemDetails bool `yaml:"problem-details,omitempty"`
+	// StrictErrorMapping makes strict servers send the errors returned by
+	// handlers as one of the operation's declared JSON error responses,
+	// rather than passing them to the error handler. Errors implementing the
+	// generated `StrictErrorResponse` interface choose the status code and
+	// body of the response, and `StrictErrorMiddleware` converts other errors
+	// with mappings registered with `MapStrictError`.
+	StrictErrorMapping bool `yaml:"strict-error-mapping,omitempty"`
+	// StrictContentNegotiation makes strict servers negotiate the content
+	// type of the response from the request's `Accept` header, for
+	// operations with a response of several content types. The chosen
+	// content type is the request object's `NegotiatedContentType`, and
+	// handlers may return a `<Operation><Status>MultiResponse` holding
+	// several representations, of which the one the `Accept` header prefers
+	// is sent. Requests accepting none of the content types are answered
+	// with `406 Not Acceptable`.
+	StrictContentNegotiation bool `yaml:"strict-content-negotiation,omitempty"`
+	// OperationRegistry generates an `Operations` table describing every
+	// operation: its method, path template, operation ID, tags, security
+	// requirements and deprecation. The generated router wrappers put the
+	// entry of the operation handling a request into the request's context
+	// before calling any middleware, where `OperationInfoFromContext` finds
+	// it, so that middlewares can label metrics and traces with the path
+	// template rather than the raw path.
+	OperationRegistry bool `yaml:"operation-registry,omitempty"`
+	// RouteMiddlewares adds `TagMiddlewares`, `NamedMiddlewares` and
+	// `OperationMiddlewares` to the server options of the net/http family of
+	// servers, so that each route gets its own middleware chain, selected by
+	// the operation's tags, the names in its `x-oapi-codegen-middlewares`
+	// extension and its operation ID, in addition to `Middlewares`.
+	RouteMiddlewares bool `yaml:"route-middlewares,omitempty"`
+	// SplitServerInterfaceByTag generates, in addition to the
+	// `ServerInterface`, a `<Tag>Server` interface for the operations of each
+	// tag, and a `NewServerInterface` constructor combining an
+	// implementation of each, and likewise for the `StrictServerInterface`.
+	// An operation belongs to its first tag, unless its
+	// `x-oapi-codegen-group` extension names another group.
+	SplitServerInterfaceByTag bool `yaml:"split-server-interface-by-tag,omitempty"`
+	// SplitClientByTag adds a field to the client, and the
+	// `ClientWithResponses`, for each tag, holding a sub-client with the
+	// operations of the tag, such as `c.Pets.FindPets(ctx)`. Sub-clients
+	// share the `Server`, `Client` and `RequestEditors` of their client,
+	// whose own methods remain. Operations are grouped as with
+	// SplitServerInterfaceByTag.
+	SplitClientByTag bool `yaml:"split-client-by-tag,omitempty"`
+	// CORS adds `CORSOptions` to the server options, and registers a handler
+	// answering the CORS preflight requests of each path, whose allowed
+	// methods are those of the path's operations, and whose allowed headers
+	// are those the operations declare. The responses to the operations get
+	// CORS headers too.
+	CORS bool `yaml:"cors,omitempty"`
+	// MethodNotAllowed registers a handler answering the requests of each
+	// path with a method of the API which none of its operations has with a
+	// 405 Method Not Allowed, whose `Allow` header lists the path's methods.
+	// The std-http server's ServeMux does so already, so it registers none.
+	MethodNotAllowed bool `yaml:"method-not-allowed,omitempty"`
+	// HeadFromGet serves the HEAD requests of each path which has a GET
+	// operation but no HEAD operation with the GET operation's handler, whose
+	// response body the server discards.
+	HeadFromGet bool `yaml:"head-from-get,omitempty"`
+	// ServeSpec registers handlers serving the embedded spec, as JSON and as
+	// YAML, on the generated router, under the server options' `BaseURL`,
+	// with ETags. The `Spec` field of the server options rewrites the
+	// `servers` of the served spec.
+	ServeSpec *ServeSpecOptions `yaml:"serve-spec,omitempty"`
+	// Fingerprint records a hash of the inputs of the generated code in its
+	// header: the spec after the overlay, the documents of its external
+	// references, the configuration and the generator's version, along with
+	// where they are. `oapi-codegen -stale` reports, or regenerates, the
+	// generated files whose inputs have changed since.
+	Fingerprint bool `yaml:"fingerprint,omitempty"`
 
 	// DisableTypeAliasesForType allows defining which OpenAPI `type`s will explicitly not use type aliases
 	// Currently supports:
@@ -536,6 +684,12 @@ type OutputOptions struct {
 	// User-specified mappings are merged on top of the defaults.
 	TypeMapping *TypeMapping `yaml:"type-mapping,omitempty"`
 
+	// FormatTypes maps the `time`, `decimal`, `int64` and `uint64` string
+	// formats, and the `decimal` number format, to the types of
+	// github.com/oapi-codegen/oapi-codegen/v2/pkg/types, rather than to
+	// `string` and `float32`. TypeMapping still takes precedence.
+	FormatTypes bool `yaml:"format-types,omitempty"`
+
 	// ContentTypes maps a short name to a list of regex patterns matched
 	// against request/response media types. When a media type matches a
 	// pattern, the short name is used as the tag in generated type names
@@ -572,6 +726,20 @@ type OutputOptions struct {
 	ContentTypes map[string][]string `yaml:"content-types,omitempty"`
 }
 
+// ServeSpecOptions configures the handlers serving the embedded spec, with
+// output-options.serve-spec.
+type ServeSpecOptions struct {
+	// JSONPath is the path of the spec as JSON, `/openapi.json` by default.
+	JSONPath string `yaml:"json-path,omitempty"`
+	// YAMLPath is the path of the spec as YAML, `/openapi.yaml` by default.
+	YAMLPath string `yaml:"yaml-path,omitempty"`
+	// Public serves the spec without the operations whose `x-internal`
+	// extension is true, and the components which no other operation uses.
+	// By default, the spec is served as it was generated from, after
+	// filtering and pruning.
+	Public bool `yaml:"public,omitempty"`
+}
+
 func (oo OutputOptions) Validate() map[string]string {
 	if NameNormalizerFunction(oo.NameNormalizer) != NameNormalizerFunctionToCamelCaseWithInitialisms && len(oo.AdditionalInitialisms) > 0 {
 		return map[string]string{
@@ -0,0 +1,215 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// ConstructorDefinition describes the constructor generated for a struct
+// type, and the functional options which set its optional properties.
+type ConstructorDefinition struct {
+	TypeName string
+	// FuncName is the name of the constructor, usually `New<TypeName>`.
+	FuncName string
+	// OptionType is the name of the functional option type, usually
+	// `<TypeName>Option`.
+	OptionType string
+	// Params holds the positional arguments, one for each required property.
+	Params []ConstructorParam
+	// Options holds a functional option for each optional property.
+	Options []ConstructorOption
+}
+
+// ConstructorParam is a positional argument of a constructor, which sets a
+// required property.
+type ConstructorParam struct {
+	// Name is the name of the argument.
+	Name string
+	// Type is the Go type of the argument.
+	Type string
+	// Field is the path to the struct field it sets, such as `Owner.Name`.
+	Field string
+	// Value is the expression assigned to the field, in terms of Name.
+	Value string
+}
+
+// ConstructorOption is a functional option which sets an optional property.
+type ConstructorOption struct {
+	// FuncName is the name of the option, such as `WithPetTag`.
+	FuncName string
+	// Type is the Go type of the option's argument.
+	Type string
+	// Field is the path to the struct field it sets, such as `Owner.Email`.
+	Field string
+	// Value is the expression assigned to the field, in terms of `v`.
+	Value string
+}
+
+// GenerateConstructors generates a `New<Type>` constructor for every
+// generated struct type with properties. Required properties are positional
+// arguments, and optional properties are set with functional options.
+// Read-only properties are set by the server, so they're left out. The
+// constructors are named so as not to collide with the declared names of
+// the rest of the generated code, as returned by declaredNames.
+func GenerateConstructors(t *template.Template, typeDefs []TypeDefinition, declared map[string]bool) (string, error) {
+	// The generated identifiers mustn't collide with the generated types,
+	// the other declarations, nor with each other.
+	taken := maps.Clone(declared)
+	if taken == nil {
+		taken = map[string]bool{}
+	}
+	for _, td := range typeDefs {
+		taken[td.TypeName] = true
+	}
+
+	var defs []ConstructorDefinition
+	seen := map[string]bool{}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] || td.IsAlias() || td.Schema.RefType != "" ||
+			len(td.Schema.Properties) == 0 || !strings.HasPrefix(td.Schema.GoType, "struct") {
+			continue
+		}
+		seen[td.TypeName] = true
+
+		def := ConstructorDefinition{
+			TypeName:   td.TypeName,
+			FuncName:   "New" + td.TypeName,
+			OptionType: td.TypeName + "Option",
+		}
+		// A schema may be called `NewPet`, as in the petstore examples, and
+		// the client declares `New<OperationId>Request`, so fall back to
+		// `MakePet` for the constructor of `Pet`.
+		if taken[def.FuncName] {
+			def.FuncName = "Make" + td.TypeName
+		}
+		params := map[string]bool{"t": true, "opts": true}
+		def.addProperties(td.Schema.Properties, "", "", params)
+
+		names := []string{def.FuncName, def.OptionType}
+		for _, o := range def.Options {
+			names = append(names, o.FuncName)
+		}
+		for _, name := range names {
+			if taken[name] {
+				return "", fmt.Errorf("the constructor generated for %s declares %s, which is already declared; rename one of them with x-go-name", td.TypeName, name)
+			}
+			taken[name] = true
+		}
+		defs = append(defs, def)
+	}
+	if len(defs) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"constructors.tmpl"}, t, defs)
+}
+
+// declaredNames returns the names of the package-level declarations of
+// code, a Go source file, other than methods. Code which doesn't parse
+// yields the names declared before the error.
+func declaredNames(code string) map[string]bool {
+	names := map[string]bool{}
+	file, _ := parser.ParseFile(token.NewFileSet(), "", code, parser.SkipObjectResolution)
+	if file == nil {
+		return names
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// addProperties adds the arguments and options which set props. The fields
+// of required inline objects are set in turn, so that nested required
+// properties are positional arguments too.
+func (def *ConstructorDefinition) addProperties(props []Property, fieldPrefix, namePrefix string, params map[string]bool) {
+	for _, p := range props {
+		if p.ReadOnly {
+			continue
+		}
+		fieldName := p.GoFieldName()
+		field := fieldPrefix + fieldName
+		typeDef := p.GoTypeDef()
+
+		if p.Required && p.Schema.RefType == "" && len(p.Schema.Properties) != 0 &&
+			strings.HasPrefix(typeDef, "struct") {
+			def.addProperties(p.Schema.Properties, field+".", namePrefix+fieldName, params)
+			continue
+		}
+
+		if p.Required {
+			param := ConstructorParam{
+				Name:  constructorParamName(namePrefix+fieldName, params),
+				Type:  typeDef,
+				Field: field,
+			}
+			param.Value = param.Name
+			// Required write-only properties are pointers, but there's no
+			// need to take a pointer as an argument for them. Nullable
+			// properties keep theirs, so that they can be set to null.
+			if strings.HasPrefix(typeDef, "*") && !p.Nullable {
+				param.Type = strings.TrimPrefix(typeDef, "*")
+				param.Value = "&" + param.Name
+			}
+			def.Params = append(def.Params, param)
+			continue
+		}
+
+		option := ConstructorOption{
+			FuncName: "With" + def.TypeName + strings.ReplaceAll(field, ".", ""),
+			Type:     typeDef,
+			Field:    field,
+			Value:    "v",
+		}
+		switch {
+		case strings.HasPrefix(typeDef, "nullable.Nullable["):
+			option.Type = p.Schema.TypeDecl()
+			option.Value = "nullable.NewNullableWithValue(v)"
+		case p.IsOptionalType():
+			option.Type = p.Schema.TypeDecl()
+			option.Value = "NewOptional(v)"
+		case strings.HasPrefix(typeDef, "*"):
+			option.Type = strings.TrimPrefix(typeDef, "*")
+			option.Value = "&v"
+		}
+		def.Options = append(def.Options, option)
+	}
+}
+
+// constructorParamName returns a unique, valid argument name for the given
+// field, and records it in params.
+func constructorParamName(fieldName string, params map[string]bool) string {
+	name := LowercaseFirstCharacters(fieldName)
+	if IsGoKeyword(name) || IsPredeclaredGoIdentifier(name) || params[name] {
+		name = "p" + UppercaseFirstCharacter(name)
+	}
+	if unicode.IsNumber([]rune(name)[0]) {
+		name = "n" + name
+	}
+	for i, base := 2, name; params[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	params[name] = true
+	return name
+}
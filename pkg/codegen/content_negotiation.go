@@ -0,0 +1,48 @@
+package codegen
+
+import (
+	"slices"
+	"strings"
+)
+
+// NegotiableContents returns the contents of the response which a strict
+// server chooses between, with output-options.strict-content-negotiation:
+// those with a fixed content type, when there are several of them.
+func (r ResponseDefinition) NegotiableContents() []ResponseContentDefinition {
+	var contents []ResponseContentDefinition
+	for _, c := range r.Contents {
+		if c.HasFixedContentType() {
+			contents = append(contents, c)
+		}
+	}
+	if len(contents) < 2 {
+		return nil
+	}
+	return contents
+}
+
+// NegotiableContentTypes returns the content types of the operation's
+// responses, which a strict server negotiates from the request's Accept
+// header, with output-options.strict-content-negotiation. It's nil unless
+// one of the responses has several content types.
+func (o OperationDefinition) NegotiableContentTypes() []string {
+	if !globalState.options.OutputOptions.StrictContentNegotiation {
+		return nil
+	}
+	if !slices.ContainsFunc(o.Responses, func(r ResponseDefinition) bool {
+		return r.NegotiableContents() != nil
+	}) {
+		return nil
+	}
+
+	var contentTypes []string
+	for _, r := range o.Responses {
+		for _, c := range r.Contents {
+			contentType := strings.ToLower(c.ContentType)
+			if c.HasFixedContentType() && !slices.Contains(contentTypes, contentType) {
+				contentTypes = append(contentTypes, contentType)
+			}
+		}
+	}
+	return contentTypes
+}
@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// CORSPath describes the preflight requests of a path, which servers answer
+// with output-options.cors.
+type CORSPath struct {
+	// Path is the path as it appears in the spec, such as `/pets/{id}`.
+	Path string
+	// Methods is the value of `Allow` and `Access-Control-Allow-Methods`:
+	// the methods of the path's operations, HEAD when the GET operation
+	// serves it, and OPTIONS.
+	Methods string
+	// Headers are the request headers which the path's operations declare:
+	// their header parameters, the headers of their security schemes, and
+	// `Content-Type` if they have a request body.
+	Headers []string
+}
+
+// corsPaths returns the paths of ops which get a preflight handler, in the
+// order they first appear. Paths which declare an OPTIONS operation are
+// left to it. With output-options.head-from-get, HEAD is allowed after GET
+// on the paths which don't declare it.
+func corsPaths(ops []OperationDefinition) []CORSPath {
+	var paths []CORSPath
+	var methods [][]string
+	index := map[string]int{}
+	for _, op := range ops {
+		i, ok := index[op.Path]
+		if !ok {
+			i = len(paths)
+			index[op.Path] = i
+			paths = append(paths, CORSPath{Path: op.Path})
+			methods = append(methods, nil)
+		}
+		methods[i] = append(methods[i], strings.ToUpper(op.Method))
+		paths[i].Headers = appendHeaders(paths[i].Headers, op.corsHeaders()...)
+	}
+
+	var out []CORSPath
+	for i, path := range paths {
+		if slices.Contains(methods[i], http.MethodOptions) {
+			continue
+		}
+		if get := slices.Index(methods[i], http.MethodGet); get != -1 &&
+			globalState.options.OutputOptions.HeadFromGet && !slices.Contains(methods[i], http.MethodHead) {
+			methods[i] = slices.Insert(methods[i], get+1, http.MethodHead)
+		}
+		path.Methods = strings.Join(append(methods[i], http.MethodOptions), ", ")
+		out = append(out, path)
+	}
+	return out
+}
+
+// corsHeaders returns the request headers which the operation declares.
+func (o OperationDefinition) corsHeaders() []string {
+	var headers []string
+	for _, param := range o.HeaderParams {
+		headers = appendHeaders(headers, param.ParamName)
+	}
+	for _, requirement := range o.SecurityRequirements() {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			headers = appendHeaders(headers, securitySchemeHeader(name))
+		}
+	}
+	if len(o.Bodies) != 0 {
+		headers = appendHeaders(headers, "Content-Type")
+	}
+	return headers
+}
+
+// securitySchemeHeader returns the request header which the security scheme
+// with the given name is sent in, or "" if it isn't sent in a header.
+func securitySchemeHeader(name string) string {
+	if globalState.spec == nil || globalState.spec.Components == nil {
+		return ""
+	}
+	ref, ok := globalState.spec.Components.SecuritySchemes[name]
+	if !ok || ref.Value == nil {
+		return ""
+	}
+	switch scheme := ref.Value; {
+	case scheme.Type == "apiKey" && scheme.In == "header":
+		return scheme.Name
+	case scheme.Type == "http", scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+		return "Authorization"
+	}
+	return ""
+}
+
+// appendHeaders appends the non-empty headers which aren't in headers yet,
+// ignoring case.
+func appendHeaders(headers []string, more ...string) []string {
+	for _, header := range more {
+		if header == "" || containsHeader(headers, header) {
+			continue
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func containsHeader(headers []string, header string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+	return false
+}
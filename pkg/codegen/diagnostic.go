@@ -0,0 +1,166 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Severity is the severity of a Diagnostic, named as SARIF's levels.
+type Severity string
+
+const (
+	// SeverityError is a problem which makes code generation fail, or
+	// the generated code not compile.
+	SeverityError Severity = "error"
+	// SeverityWarning is a part of the spec which isn't generated as it's
+	// written, such as a construct which is ignored, or a renamed type.
+	SeverityWarning Severity = "warning"
+	// SeverityNote is something the generator does implicitly, which the
+	// spec may want to make explicit.
+	SeverityNote Severity = "note"
+)
+
+// The codes of diagnostics.
+const (
+	// CodeInvalidValue is a value of the spec which can't be copied into the
+	// generated code, as ValidateSpec rejects.
+	CodeInvalidValue = "invalid-value"
+	// CodeTypeNameCollision is a type whose name collides with another
+	// type's: it's renamed with output-options.resolve-type-name-collisions,
+	// or declared twice without it.
+	CodeTypeNameCollision = "type-name-collision"
+	// CodeDefaultOperationID is an operation without an operationId, whose
+	// generated name is derived from its method and path.
+	CodeDefaultOperationID = "default-operation-id"
+	// CodeIgnoredPatternProperties is a schema whose patternProperties are
+	// ignored.
+	CodeIgnoredPatternProperties = "ignored-pattern-properties"
+	// CodeGenerationFailed is an error generating code, which isn't
+	// located in the spec.
+	CodeGenerationFailed = "generation-failed"
+)
+
+// Diagnostic is a problem found in a spec, in the code which would be
+// generated from it.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	// Code identifies the kind of the problem, such as
+	// CodeTypeNameCollision, or the configuration warning, such as
+	// `std-http-server`, as keyed by Configuration.Warnings and
+	// GenerateOptions.Warnings.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Pointer is the JSON pointer of the part of the spec with the problem,
+	// such as `/components/schemas/Pet`, when it's known. It points into the
+	// spec once its external references are resolved, as Generate sees it.
+	Pointer string `json:"pointer,omitempty"`
+	// File, Line and Column are where the problem is in the spec, or in the
+	// document of an external reference, when they're known.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// String returns the diagnostic as `file:line:column: severity: message
+// [code]`, without the location when it isn't known.
+func (d Diagnostic) String() string {
+	message := fmt.Sprintf("%s: %s [%s]", d.Severity, d.Message, d.Code)
+	if d.File == "" {
+		return message
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, message)
+}
+
+// newDiagnostic returns the diagnostic at the origin of a part of the spec,
+// which is unknown if origin is nil, as it is when the spec wasn't loaded
+// with its origins.
+func newDiagnostic(severity Severity, code string, origin *openapi3.Origin, format string, args ...any) Diagnostic {
+	d := Diagnostic{Severity: severity, Code: code, Message: fmt.Sprintf(format, args...)}
+	if origin != nil && origin.Key != nil {
+		d.File, d.Line, d.Column = origin.Key.File, origin.Key.Line, origin.Key.Column
+	}
+	return d
+}
+
+// jsonPointer returns the JSON pointer of the unescaped reference tokens, or
+// "" if there are none.
+func jsonPointer(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(escapeJSONPointer(token))
+	}
+	return b.String()
+}
+
+// diagnosticsError returns the error joining the messages of the errors of
+// diagnostics, or nil if none of them is an error.
+func diagnosticsError(diagnostics []Diagnostic) error {
+	var errs []error
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, errors.New(d.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GenerateWithDiagnostics generates code from spec with opts, as Generate
+// does, and returns the diagnostics of the generation along with it: the
+// warnings of opts, the problems of the spec found while generating code
+// from it, with their locations, and, when generation fails, its error,
+// which is also returned: at the schemas it's caused by, such as the types
+// whose names collide, when they're known.
+//
+// The locations of the diagnostics are only known when spec was loaded with
+// its origins, as util.LoadSwagger does.
+func GenerateWithDiagnostics(spec *openapi3.T, opts Configuration) (string, []Diagnostic, error) {
+	diagnostics := configurationDiagnostics(opts)
+	code, err := Generate(spec, opts)
+	diagnostics = append(diagnostics, globalState.diagnostics...)
+	// The errors of invalid values are already diagnostics, with their
+	// locations.
+	if err != nil && diagnosticsError(globalState.diagnostics) == nil {
+		located := locatedErrors(spec, opts, err)
+		if len(located) == 0 {
+			located = []Diagnostic{{Severity: SeverityError, Code: CodeGenerationFailed, Message: err.Error()}}
+		}
+		diagnostics = append(diagnostics, located...)
+	}
+	return code, diagnostics, err
+}
+
+// locatedErrors returns the errors of the spec which err, the error of
+// generating code from it, is caused by, at their locations, or none if
+// err isn't located in the spec.
+func locatedErrors(spec *openapi3.T, opts Configuration, err error) []Diagnostic {
+	var collision typeNameCollisionError
+	if !errors.As(err, &collision) {
+		return nil
+	}
+	// Generate has filtered the spec, as Lint would, before declaring the
+	// types whose names collide.
+	var located []Diagnostic
+	for _, d := range lintTypeNames(spec, opts) {
+		if d.Severity == SeverityError {
+			located = append(located, d)
+		}
+	}
+	sortDiagnostics(located)
+	return located
+}
+
+// configurationDiagnostics returns the warnings of opts, whose codes are
+// the keys of Configuration.Warnings and GenerateOptions.Warnings.
+func configurationDiagnostics(opts Configuration) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, warnings := range []map[string]string{opts.Generate.Warnings(), opts.Warnings()} {
+		for _, code := range SortedMapKeys(warnings) {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Code: code, Message: warnings[code]})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,117 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestGenerateWithDiagnostics(t *testing.T) {
+	t.Run("warnings", func(t *testing.T) {
+		spec, err := util.LoadSwagger("test_specs/lint.yaml")
+		require.NoError(t, err)
+
+		opts := Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		}
+		opts.OutputOptions.ResolveTypeNameCollisions = true
+		code, diagnostics, err := GenerateWithDiagnostics(spec, opts)
+		require.NoError(t, err)
+		assert.Contains(t, code, "type Pet struct")
+
+		assert.Equal(t, []Diagnostic{{
+			Severity: SeverityWarning,
+			Code:     CodeIgnoredPatternProperties,
+			Message:  `patternProperties in schema "Pet" are ignored, so the properties they match aren't generated`,
+			Pointer:  "/components/schemas/Pet",
+			File:     "test_specs/lint.yaml",
+			Line:     33,
+			Column:   5,
+		}}, diagnostics)
+	})
+
+	t.Run("configuration warnings", func(t *testing.T) {
+		spec, err := util.LoadSwagger("test_specs/lint.yaml")
+		require.NoError(t, err)
+
+		opts := Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Client: true},
+		}
+		opts.OutputOptions.GenerateTypesForAnonymousSchemas = true
+		_, diagnostics, err := GenerateWithDiagnostics(spec, opts)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, diagnostics)
+		assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+		assert.Equal(t, "generate-types-for-anonymous-schemas", diagnostics[0].Code)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		spec := schemaWithExtension(nil)
+		spec.Components.Schemas["S"].Value.Properties = openapi3.Schemas{
+			"a`b": openapi3.NewStringSchema().NewRef(),
+		}
+
+		opts := Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		}
+		opts.OutputOptions.SkipPrune = true
+		_, diagnostics, err := GenerateWithDiagnostics(spec, opts)
+		require.Error(t, err)
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, SeverityError, diagnostics[0].Severity)
+		assert.Equal(t, CodeInvalidValue, diagnostics[0].Code)
+		assert.Equal(t, "/components/schemas/S/properties/a`b", diagnostics[0].Pointer)
+		assert.Equal(t, err.Error(), diagnostics[0].Message)
+	})
+
+	t.Run("type name collisions", func(t *testing.T) {
+		spec, err := util.LoadSwagger("test_specs/lint.yaml")
+		require.NoError(t, err)
+
+		_, diagnostics, err := GenerateWithDiagnostics(spec, Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		})
+		require.Error(t, err)
+
+		var collisions []Diagnostic
+		for _, d := range diagnostics {
+			assert.NotEqual(t, CodeGenerationFailed, d.Code)
+			if d.Code == CodeTypeNameCollision {
+				collisions = append(collisions, d)
+			}
+		}
+		require.Len(t, collisions, 2)
+		assert.Equal(t, SeverityError, collisions[0].Severity)
+		assert.Equal(t, "test_specs/lint.yaml", collisions[0].File)
+		assert.Equal(t, 27, collisions[0].Line)
+		assert.Equal(t, 11, collisions[0].Column)
+		assert.Equal(t, "/components/schemas/Pet", collisions[1].Pointer)
+		assert.Equal(t, 33, collisions[1].Line)
+	})
+
+	t.Run("generation errors", func(t *testing.T) {
+		spec, err := util.LoadSwagger("test_specs/lint.yaml")
+		require.NoError(t, err)
+
+		opts := Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		}
+		opts.OutputOptions.NameNormalizer = "Unknown"
+		_, diagnostics, err := GenerateWithDiagnostics(spec, opts)
+		require.Error(t, err)
+
+		last := diagnostics[len(diagnostics)-1]
+		assert.Equal(t, Diagnostic{Severity: SeverityError, Code: CodeGenerationFailed, Message: err.Error()}, last)
+	})
+}
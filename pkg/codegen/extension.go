@@ -15,6 +15,9 @@ const (
 	extPropGoTypeSkipOptionalPointer = "x-go-type-skip-optional-pointer"
 	// extPropGoImport specifies the module to import which provides above type
 	extPropGoImport = "x-go-type-import"
+	// extGoTypeFormat is used in place of `format` when looking up the
+	// schema's Go type in the type mapping.
+	extGoTypeFormat = "x-go-type-format"
 	// extGoName is used to override a field name
 	extGoName = "x-go-name"
 	// extGoTypeName overrides a generated typename. When
@@ -33,6 +36,15 @@ const (
 	// extOapiCodegenOnlyHonourGoName is to be used to explicitly enforce the generation of a field as the `x-go-name` extension has describe it.
 	// This is intended to be used alongside the `allow-unexported-struct-field-names` Compatibility option
 	extOapiCodegenOnlyHonourGoName = "x-oapi-codegen-only-honour-go-name"
+	// extOapiCodegenMiddlewares lists the names of the middlewares, from the
+	// server options' NamedMiddlewares, which apply to an operation.
+	extOapiCodegenMiddlewares = "x-oapi-codegen-middlewares"
+	// extOapiCodegenGroup overrides the group, by default the first tag, of
+	// an operation, whose server interface or sub-client declares it.
+	extOapiCodegenGroup = "x-oapi-codegen-group"
+	// extInternal marks an operation which the public view of the spec,
+	// served with output-options.serve-spec.public, leaves out.
+	extInternal = "x-internal"
 )
 
 func extString(extPropValue any) (string, error) {
@@ -126,3 +138,31 @@ func extParseOapiCodegenOnlyHonourGoName(extPropValue any) (bool, error) {
 	}
 	return onlyHonourGoName, nil
 }
+
+func extParseOapiCodegenMiddlewares(extPropValue any) ([]string, error) {
+	namesI, ok := extPropValue.([]any)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	names := make([]string, len(namesI))
+	for i, v := range namesI {
+		vs, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert type: %T", v)
+		}
+		names[i] = vs
+	}
+	return names, nil
+}
+
+func extParseOapiCodegenGroup(extPropValue any) (string, error) {
+	return extString(extPropValue)
+}
+
+func extParseInternal(extPropValue any) (bool, error) {
+	internal, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return internal, nil
+}
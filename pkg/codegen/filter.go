@@ -2,6 +2,17 @@ package codegen
 
 import "github.com/getkin/kin-openapi/openapi3"
 
+// filterSpec filters the operations of spec by the tags and the operation
+// IDs of opts, then prunes its unused components, unless
+// output-options.skip-prune is set, or spec only defines models.
+func filterSpec(spec *openapi3.T, opts Configuration, modelsOnly bool) {
+	filterOperationsByTag(spec, opts)
+	filterOperationsByOperationID(spec, opts)
+	if !opts.OutputOptions.SkipPrune && !modelsOnly {
+		pruneUnusedComponents(spec)
+	}
+}
+
 func sliceToMap(items []string) map[string]bool {
 	m := make(map[string]bool, len(items))
 	for _, item := range items {
@@ -0,0 +1,107 @@
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+)
+
+// fingerprintDirective starts the header line recording the fingerprint of
+// the generated code, with output-options.fingerprint.
+const fingerprintDirective = "//oapi-codegen:fingerprint"
+
+// FingerprintSource describes the inputs which the code is generated from,
+// for the fingerprint which output-options.fingerprint records in the header
+// of the generated code. The CLI sets it.
+type FingerprintSource struct {
+	// SpecDigest is the SHA-256 digest of the documents which the spec was
+	// loaded from, as returned by util.LoadSwaggerWithOverlayAndDigest. It
+	// defaults to the digest of the spec as JSON.
+	SpecDigest string
+	// Dir is the directory the generator ran in, relative to the directory
+	// of the generated file.
+	Dir string
+	// Config is the path of the configuration file, relative to Dir.
+	Config string
+	// Spec is the path, or the URL, of the spec, relative to Dir.
+	Spec string
+}
+
+// Fingerprint is the fingerprint recorded in the header of generated code,
+// with output-options.fingerprint.
+type Fingerprint struct {
+	FingerprintSource
+	// Hash is the hash of the inputs, as returned by InputsHash.
+	Hash string
+}
+
+// String returns the header line recording f.
+func (f Fingerprint) String() string {
+	return fmt.Sprintf("%s sha256:%s dir=%q config=%q spec=%q", fingerprintDirective, f.Hash, f.Dir, f.Config, f.Spec)
+}
+
+// ParseFingerprint returns the fingerprint recorded in the header of the
+// generated code, and whether it records one.
+func ParseFingerprint(code []byte) (Fingerprint, bool, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(code))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !strings.HasPrefix(line, fingerprintDirective+" ") {
+			continue
+		}
+		var f Fingerprint
+		_, err := fmt.Sscanf(line, fingerprintDirective+" sha256:%s dir=%q config=%q spec=%q", &f.Hash, &f.Dir, &f.Config, &f.Spec)
+		if err != nil {
+			return Fingerprint{}, false, fmt.Errorf("invalid fingerprint %q: %w", line, err)
+		}
+		return f, true, nil
+	}
+	return Fingerprint{}, false, scanner.Err()
+}
+
+// InputsHash returns the hex-encoded SHA-256 hash of the inputs which code is
+// generated from: the digest of the spec's documents, the configuration,
+// and the version of the generator. The code is stale once it changes.
+func InputsHash(opts Configuration, specDigest string) (string, error) {
+	config, err := yaml.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling configuration: %w", err)
+	}
+	_, version := generatorVersion(opts.NoVCSVersionOverride)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "version %s\nspec %s\n", version, specDigest)
+	h.Write(config)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// generateFingerprint returns the fingerprint of the code generated from
+// spec with opts, which must be called before spec is filtered.
+func generateFingerprint(spec *openapi3.T, opts Configuration) (Fingerprint, error) {
+	var source FingerprintSource
+	if opts.FingerprintSource != nil {
+		source = *opts.FingerprintSource
+	}
+	if source.SpecDigest == "" {
+		data, err := spec.MarshalJSON()
+		if err != nil {
+			return Fingerprint{}, fmt.Errorf("error marshaling swagger: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		source.SpecDigest = hex.EncodeToString(sum[:])
+	}
+	hash, err := InputsHash(opts, source.SpecDigest)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+	return Fingerprint{FingerprintSource: source, Hash: hash}, nil
+}
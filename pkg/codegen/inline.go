@@ -29,25 +29,60 @@ import (
 func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T) (string, error) {
 	// ensure that any external file references are embedded into the embedded spec
 	swagger.InternalizeRefs(context.Background(), nil)
+	parts, err := encodeSpec(swagger)
+	if err != nil {
+		return "", err
+	}
+
+	// The public view which output-options.serve-spec serves is embedded
+	// alongside the spec.
+	var publicParts []string
+	if serveSpec := globalState.options.OutputOptions.ServeSpec; serveSpec != nil && serveSpec.Public {
+		public, err := publicSpec(swagger)
+		if err != nil {
+			return "", fmt.Errorf("error generating public spec: %w", err)
+		}
+		if publicParts, err = encodeSpec(public); err != nil {
+			return "", err
+		}
+	}
+
+	return GenerateTemplates(
+		[]string{"inline.tmpl"},
+		t,
+		struct {
+			SpecParts       []string
+			PublicSpecParts []string
+			ImportMapping   importMap
+		}{
+			SpecParts:       parts,
+			PublicSpecParts: publicParts,
+			ImportMapping:   importMapping,
+		})
+}
+
+// encodeSpec returns the JSON representation of swagger, compressed with
+// deflate and base64 encoded, in fixed-width chunks.
+func encodeSpec(swagger *openapi3.T) ([]string, error) {
 	// Marshal to json
 	encoded, err := swagger.MarshalJSON()
 	if err != nil {
-		return "", fmt.Errorf("error marshaling swagger: %w", err)
+		return nil, fmt.Errorf("error marshaling swagger: %w", err)
 	}
 
 	// flate
 	var buf bytes.Buffer
 	zw, err := flate.NewWriter(&buf, flate.BestCompression)
 	if err != nil {
-		return "", fmt.Errorf("new flate writer: %w", err)
+		return nil, fmt.Errorf("new flate writer: %w", err)
 	}
 
 	if _, err := zw.Write(encoded); err != nil {
-		return "", fmt.Errorf("write flate: %w", err)
+		return nil, fmt.Errorf("write flate: %w", err)
 	}
 
 	if err := zw.Close(); err != nil {
-		return "", fmt.Errorf("close flate writer: %w", err)
+		return nil, fmt.Errorf("close flate writer: %w", err)
 	}
 
 	str := base64.StdEncoding.EncodeToString(buf.Bytes())
@@ -64,15 +99,5 @@ func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger
 	if len(str) > 0 {
 		parts = append(parts, str)
 	}
-
-	return GenerateTemplates(
-		[]string{"inline.tmpl"},
-		t,
-		struct {
-			SpecParts     []string
-			ImportMapping importMap
-		}{
-			SpecParts:     parts,
-			ImportMapping: importMapping,
-		})
+	return parts, nil
 }
@@ -0,0 +1,181 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// Lint reports the problems of spec which are specific to generating code
+// from it with opts, at their locations in the spec when it was loaded with
+// its origins, as util.LoadSwagger does: the values ValidateSpec rejects,
+// the type names which collide, the operations whose names are derived from
+// their method and path, and the constructs which are ignored.
+//
+// Like Generate, Lint filters spec in place, so that only the parts of the
+// spec which code is generated from are reported.
+func Lint(spec *openapi3.T, opts Configuration) ([]Diagnostic, error) {
+	globalState.options = opts
+	globalState.spec = spec
+	if err := setNameNormalizer(opts); err != nil {
+		return nil, err
+	}
+	if opts.OutputOptions.ResponseTypeSuffix != "" {
+		responseTypeSuffix = opts.OutputOptions.ResponseTypeSuffix
+	}
+
+	modelsOnly := util.IsModelsOnlySpec(spec)
+	if modelsOnly && opts.Generate != (GenerateOptions{Models: true}) {
+		return nil, fmt.Errorf("only models can be generated from JSON Schema and AsyncAPI documents")
+	}
+	filterSpec(spec, opts, modelsOnly)
+
+	diagnostics := validateSpec(spec)
+	diagnostics = append(diagnostics, lintOperationIDs(spec)...)
+	diagnostics = append(diagnostics, lintTypeNames(spec, opts)...)
+
+	sortDiagnostics(diagnostics)
+	return diagnostics, nil
+}
+
+// sortDiagnostics sorts diagnostics by their locations, keeping the order of
+// those at the same location.
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// lintOperationIDs notes the operations without an operationId, whose names
+// in the generated code are derived from their method and path.
+func lintOperationIDs(spec *openapi3.T) []Diagnostic {
+	if spec.Paths == nil {
+		return nil
+	}
+	var diagnostics []Diagnostic
+	for _, path := range SortedMapKeys(spec.Paths.Map()) {
+		operations := spec.Paths.Value(path).Operations()
+		for _, method := range SortedMapKeys(operations) {
+			op := operations[method]
+			if op.OperationID != "" {
+				continue
+			}
+			operationID, err := generateDefaultOperationID(method, path)
+			if err != nil {
+				continue
+			}
+			operationID = typeNamePrefix(operationID) + operationID
+			d := newDiagnostic(SeverityNote, CodeDefaultOperationID, op.Origin,
+				"%s %s has no operationId, so it's named %s after its method and path", method, path, operationID)
+			d.Pointer = jsonPointer("paths", path, strings.ToLower(method))
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// lintTypeNames reports the types whose names collide. With
+// output-options.resolve-type-name-collisions they're renamed, which is
+// warned about, and without it they're declared twice, which is an error.
+func lintTypeNames(spec *openapi3.T, opts Configuration) []Diagnostic {
+	names := resolveNames(GatherSchemas(spec, opts))
+
+	byCandidate := map[string][]*ResolvedName{}
+	for _, name := range names {
+		byCandidate[name.Candidate] = append(byCandidate[name.Candidate], name)
+	}
+	others := func(name *ResolvedName, declaredOnly bool) []string {
+		var paths []string
+		for _, other := range byCandidate[name.Candidate] {
+			if other != name && (!declaredOnly || isDeclaredWithoutResolving(other.Schema)) {
+				paths = append(paths, fmt.Sprintf("%q", other.Schema.Path.String()))
+			}
+		}
+		return paths
+	}
+
+	var diagnostics []Diagnostic
+	for _, name := range names {
+		var origin *openapi3.Origin
+		pointer := jsonPointer(name.Schema.Path...)
+		switch {
+		case name.Schema.Context == ContextClientResponseWrapper:
+			// The response wrappers of the client are located at their
+			// operations, whose paths and methods their paths hold.
+			pointer = jsonPointer("paths", name.Schema.Path[1], strings.ToLower(name.Schema.Path[2]))
+			if pathItem := spec.Paths.Value(name.Schema.Path[1]); pathItem != nil {
+				if op := pathItem.GetOperation(name.Schema.Path[2]); op != nil {
+					origin = op.Origin
+				}
+			}
+		case name.Schema.Schema != nil:
+			origin = name.Schema.Schema.Origin
+			// The paths of the schemas of media types, parameters and
+			// headers stop at the objects holding them.
+			if holdsSchema(name.Schema.Context) {
+				pointer = jsonPointer(append(name.Schema.Path, "schema")...)
+			}
+		}
+		path := name.Schema.Path.String()
+
+		if opts.OutputOptions.ResolveTypeNameCollisions {
+			if name.GoName != name.Candidate {
+				d := newDiagnostic(SeverityWarning, CodeTypeNameCollision, origin,
+					"the type of %q is named %s rather than %s, which is also the name of the type of %s; set x-go-name to name it explicitly",
+					path, name.GoName, name.Candidate, strings.Join(others(name, false), ", "))
+				d.Pointer = pointer
+				diagnostics = append(diagnostics, d)
+			}
+			continue
+		}
+
+		// Without resolve-type-name-collisions, the types of the components
+		// and the response wrappers of the client are named as their
+		// candidates, and the types of operations are inlined.
+		if !isDeclaredWithoutResolving(name.Schema) {
+			continue
+		}
+		if colliding := others(name, true); len(colliding) > 0 {
+			d := newDiagnostic(SeverityError, CodeTypeNameCollision, origin,
+				"the type of %q is named %s, as is the type of %s; set output-options.resolve-type-name-collisions, or x-go-name to rename it",
+				path, name.Candidate, strings.Join(colliding, ", "))
+			d.Pointer = pointer
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// holdsSchema reports whether the paths of the gathered schemas of context
+// are those of the objects holding the schemas, under their `schema` keys.
+func holdsSchema(context SchemaContext) bool {
+	switch context {
+	case ContextComponentParameter, ContextComponentRequestBody, ContextComponentResponse, ContextComponentHeader:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDeclaredWithoutResolving reports whether the type of the gathered schema
+// is declared with the name of its candidate when type name collisions
+// aren't resolved.
+func isDeclaredWithoutResolving(gs *GatheredSchema) bool {
+	switch gs.Context {
+	case ContextOperationParameter, ContextOperationRequestBody, ContextOperationResponse:
+		return false
+	default:
+		return true
+	}
+}
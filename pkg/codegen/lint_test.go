@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestLint(t *testing.T) {
+	const specPath = "test_specs/lint.yaml"
+
+	t.Run("without resolving type name collisions", func(t *testing.T) {
+		spec, err := util.LoadSwagger(specPath)
+		require.NoError(t, err)
+
+		diagnostics, err := Lint(spec, Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		})
+		require.NoError(t, err)
+
+		var got, collisions []string
+		for _, d := range diagnostics {
+			got = append(got, d.String())
+			if d.Code == CodeTypeNameCollision {
+				collisions = append(collisions, d.Pointer)
+			}
+		}
+		assert.Equal(t, []string{
+			specPath + `:12:5: note: POST /pets has no operationId, so it's named PostPets after its method and path [default-operation-id]`,
+			specPath + `:27:11: error: the type of "components/responses/Pet/content/application/json" is named Pet, as is the type of "components/schemas/Pet"; set output-options.resolve-type-name-collisions, or x-go-name to rename it [type-name-collision]`,
+			specPath + `:33:5: warning: patternProperties in schema "Pet" are ignored, so the properties they match aren't generated [ignored-pattern-properties]`,
+			specPath + `:33:5: error: the type of "components/schemas/Pet" is named Pet, as is the type of "components/responses/Pet/content/application/json"; set output-options.resolve-type-name-collisions, or x-go-name to rename it [type-name-collision]`,
+		}, got)
+		assert.Equal(t, []string{
+			"/components/responses/Pet/content/application~1json/schema",
+			"/components/schemas/Pet",
+		}, collisions)
+	})
+
+	t.Run("resolving type name collisions", func(t *testing.T) {
+		spec, err := util.LoadSwagger(specPath)
+		require.NoError(t, err)
+
+		opts := Configuration{
+			PackageName: "lint",
+			Generate:    GenerateOptions{Models: true},
+		}
+		opts.OutputOptions.ResolveTypeNameCollisions = true
+		diagnostics, err := Lint(spec, opts)
+		require.NoError(t, err)
+
+		var collisions []Diagnostic
+		for _, d := range diagnostics {
+			if d.Code == CodeTypeNameCollision {
+				collisions = append(collisions, d)
+			}
+		}
+		require.Len(t, collisions, 1)
+		assert.Equal(t, SeverityWarning, collisions[0].Severity)
+		assert.Equal(t, 27, collisions[0].Line)
+		assert.Equal(t, "/components/responses/Pet/content/application~1json/schema", collisions[0].Pointer)
+		assert.Contains(t, collisions[0].Message, "is named PetResponse rather than Pet")
+	})
+
+	t.Run("models only documents", func(t *testing.T) {
+		spec, err := util.LoadSwagger("test_specs/jsonschema/order.schema.json")
+		require.NoError(t, err)
+
+		_, err = Lint(spec, Configuration{PackageName: "lint", Generate: GenerateOptions{Client: true}})
+		assert.Error(t, err)
+	})
+}
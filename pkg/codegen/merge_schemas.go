@@ -267,7 +267,7 @@ func mergeOpenapiSchemas(s1, s2 openapi3.Schema, allOf bool, seenSchemaRef map[s
 	// We are going to make AllOf transitive, so that merging an AllOf that
 	// contains AllOf's will result in a flat object.
 	var err error
-	if s1.AllOf != nil {
+	if len(s1.AllOf) != 0 {
 		var merged openapi3.Schema
 		merged, err = mergeAllOf(s1.AllOf, seenSchemaRef)
 		if err != nil {
@@ -277,7 +277,7 @@ func mergeOpenapiSchemas(s1, s2 openapi3.Schema, allOf bool, seenSchemaRef map[s
 		anyOf = append(anyOf, merged.AnyOf...)
 		s1 = merged
 	}
-	if s2.AllOf != nil {
+	if len(s2.AllOf) != 0 {
 		var merged openapi3.Schema
 		merged, err = mergeAllOf(s2.AllOf, seenSchemaRef)
 		if err != nil {
@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"net/http"
+	"strings"
+)
+
+// routableMethods are the methods which servers may answer with a 405 Method
+// Not Allowed on the paths which don't declare them, with
+// output-options.method-not-allowed, in the order `Allow` lists them.
+var routableMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodConnect,
+}
+
+// PathMethods describes the methods of a path as the routers serve them.
+type PathMethods struct {
+	// Path is the path as it appears in the spec, such as `/pets/{id}`.
+	Path string
+	// Allow is the value of the `Allow` header of the path's 405 responses.
+	Allow string
+	// NotAllowed are the methods which are answered with a 405.
+	NotAllowed []string
+	// HeadFrom is the GET operation which also serves HEAD requests, when
+	// the path doesn't declare a HEAD operation.
+	HeadFrom *OperationDefinition
+}
+
+// pathMethods returns the methods of the paths of ops, in the order the paths
+// first appear. With headFromGet, the GET operation of a path serves its
+// HEAD requests too. OPTIONS is allowed on every path with
+// output-options.cors, which answers preflight requests.
+//
+// Only the methods of the operations of ops are answered with a 405 on the
+// other paths, so that routers aren't registered with methods which the API
+// doesn't use, such as TRACE. HEAD never is on a path with a GET operation,
+// whose HEAD requests routers like net/http's ServeMux serve with it.
+func pathMethods(ops []OperationDefinition, headFromGet bool) []PathMethods {
+	var paths []PathMethods
+	declared := map[string]map[string]*OperationDefinition{}
+	used := map[string]bool{}
+	for i, op := range ops {
+		methods, ok := declared[op.Path]
+		if !ok {
+			methods = map[string]*OperationDefinition{}
+			declared[op.Path] = methods
+			paths = append(paths, PathMethods{Path: op.Path})
+		}
+		method := strings.ToUpper(op.Method)
+		methods[method] = &ops[i]
+		used[method] = true
+	}
+
+	cors := globalState.options.OutputOptions.CORS
+	for i := range paths {
+		methods := declared[paths[i].Path]
+		get := methods[http.MethodGet]
+		if get != nil && headFromGet && methods[http.MethodHead] == nil {
+			paths[i].HeadFrom = get
+		}
+		var allow []string
+		for _, method := range routableMethods {
+			_, ok := methods[method]
+			switch {
+			case ok,
+				method == http.MethodHead && paths[i].HeadFrom != nil,
+				method == http.MethodOptions && cors:
+				allow = append(allow, method)
+			case method == http.MethodHead && get != nil:
+			case used[method]:
+				paths[i].NotAllowed = append(paths[i].NotAllowed, method)
+			}
+		}
+		paths[i].Allow = strings.Join(allow, ", ")
+	}
+	return paths
+}
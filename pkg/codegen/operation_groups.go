@@ -0,0 +1,106 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+)
+
+// defaultOperationGroup is the group of the operations which have neither a
+// tag nor an `x-oapi-codegen-group`.
+const defaultOperationGroup = "default"
+
+// OperationGroup is a group of operations which get their own server
+// interface, with output-options.split-server-interface-by-tag, or their own
+// sub-client, with output-options.split-client-by-tag.
+type OperationGroup struct {
+	// Name is the group's name as it appears in the spec: a tag, or the
+	// value of `x-oapi-codegen-group`.
+	Name string
+	// GoName is the prefix of the group's types, such as `Pets` for
+	// `PetsServer`.
+	GoName string
+	// Operations are the operations of the group.
+	Operations []OperationDefinition
+}
+
+// GroupName returns the name of the group of the operation: its
+// `x-oapi-codegen-group`, or else its first tag.
+func (o OperationDefinition) GroupName() (string, error) {
+	if o.Spec == nil {
+		return defaultOperationGroup, nil
+	}
+	if extension, ok := o.Spec.Extensions[extOapiCodegenGroup]; ok {
+		name, err := extParseOapiCodegenGroup(extension)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q of operation %s: %w", extOapiCodegenGroup, o.OperationId, err)
+		}
+		if name == "" {
+			return "", fmt.Errorf("empty %q of operation %s", extOapiCodegenGroup, o.OperationId)
+		}
+		return name, nil
+	}
+	if len(o.Spec.Tags) != 0 {
+		return o.Spec.Tags[0], nil
+	}
+	return defaultOperationGroup, nil
+}
+
+// groupOperations groups ops, ordered by GoName. Groups whose names have the
+// same GoName are merged.
+func groupOperations(ops []OperationDefinition) ([]OperationGroup, error) {
+	var groups []OperationGroup
+	index := map[string]int{}
+	for _, op := range ops {
+		name, err := op.GroupName()
+		if err != nil {
+			return nil, err
+		}
+		goName := SchemaNameToTypeName(name)
+		i, ok := index[goName]
+		if !ok {
+			i = len(groups)
+			index[goName] = i
+			groups = append(groups, OperationGroup{Name: name, GoName: goName})
+		}
+		groups[i].Operations = append(groups[i].Operations, op)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].GoName < groups[j].GoName
+	})
+	return groups, nil
+}
+
+// serverGroups groups the operations which the server interfaces declare.
+func serverGroups(ops []OperationDefinition) ([]OperationGroup, error) {
+	var declared []OperationDefinition
+	for _, op := range ops {
+		if !op.IsAlias {
+			declared = append(declared, op)
+		}
+	}
+	return groupOperations(declared)
+}
+
+// clientGroups groups the operations of the client. Each group is a field of
+// the client, so its GoName mustn't be the name of another of the client's
+// fields or methods.
+func clientGroups(ops []OperationDefinition) ([]OperationGroup, error) {
+	groups, err := groupOperations(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := map[string]bool{"Server": true, "Client": true, "RequestEditors": true, "ClientInterface": true}
+	for _, op := range ops {
+		for _, variant := range op.ClientMethodVariants() {
+			taken[op.OperationId+variant.Suffix] = true
+			taken[op.OperationId+variant.Suffix+"WithResponse"] = true
+		}
+	}
+	for _, group := range groups {
+		if taken[group.GoName] {
+			return nil, fmt.Errorf("the client field %s of the %q group of operations collides with a field or method of the client", group.GoName, group.Name)
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,27 @@
+package codegen
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// SecurityRequirements returns the security requirements of the operation,
+// of which a request must satisfy one. Each maps the security schemes it
+// needs to their scopes. The operation's own `security` overrides the
+// spec's.
+func (o OperationDefinition) SecurityRequirements() []map[string][]string {
+	var requirements openapi3.SecurityRequirements
+	switch {
+	case o.Spec != nil && o.Spec.Security != nil:
+		requirements = *o.Spec.Security
+	case globalState.spec != nil:
+		requirements = globalState.spec.Security
+	}
+
+	var out []map[string][]string
+	for _, requirement := range requirements {
+		schemes := map[string][]string{}
+		for name, scopes := range requirement {
+			schemes[name] = scopes
+		}
+		out = append(out, schemes)
+	}
+	return out
+}
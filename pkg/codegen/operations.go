@@ -56,6 +56,9 @@ func (pd ParameterDefinition) TypeDef() string {
 // RequiresNilCheck indicates whether the generated property should have a nil check performed on it before other checks.
 // This should be used in templates when performing `nil` checks, but NOT when i.e. determining if there should be an optional pointer given to the type - in that case, use `HasOptionalPointer`
 func (pd ParameterDefinition) RequiresNilCheck() bool {
+	if pd.IsOptionalType() {
+		return false
+	}
 	return pd.ZeroValueIsNil() || pd.HasOptionalPointer()
 }
 
@@ -259,7 +262,23 @@ func (pd ParameterDefinition) IndirectOptional() bool {
 // HasOptionalPointer indicates whether the generated property has an optional pointer associated with it.
 // This takes into account the `x-go-type-skip-optional-pointer` extension, allowing a parameter definition to control whether the pointer should be skipped.
 func (pd ParameterDefinition) HasOptionalPointer() bool {
-	return !pd.Required && !pd.Schema.SkipOptionalPointer
+	return !pd.Required && !pd.Schema.SkipOptionalPointer && !pd.IsOptionalType()
+}
+
+// IsOptionalType indicates whether the parameter's field on the Params struct
+// is wrapped in the generated `Optional[T]` type, which
+// `output-options.optional-type` uses in place of the optional pointer.
+// Templates check presence with `IsSet()`, read the value with `MustGet()`,
+// and wrap bound values with `NewOptional`. As with properties, nullable
+// parameters keep their pointer.
+func (pd ParameterDefinition) IsOptionalType() bool {
+	return globalState.options.OutputOptions.OptionalType &&
+		!pd.Required && !pd.IsNullable() && !pd.Schema.SkipOptionalPointer
+}
+
+// IsNullable indicates whether the parameter's schema is nullable.
+func (pd ParameterDefinition) IsNullable() bool {
+	return pd.Spec.Schema != nil && schemaIsNullable(pd.Spec.Schema.Value)
 }
 
 type ParameterDefinitions []ParameterDefinition
@@ -2363,9 +2382,13 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 			JsonFieldName: param.ParamName,
 			Required:      param.Required,
 			Schema:        pSchema,
-			NeedsFormTag:  param.Style() == "form",
-			Extensions:    extensions,
-			Deprecated:    param.Spec.Deprecated,
+			// Required parameters are bound to values, so only optional
+			// ones are nullable pointers.
+			Nullable:     !param.Required && param.IsNullable(),
+			NeedsFormTag: param.Style() == "form",
+			Parameter:    true,
+			Extensions:   extensions,
+			Deprecated:   param.Spec.Deprecated,
 		}
 		s.Properties = append(s.Properties, prop)
 	}
@@ -2574,6 +2597,33 @@ func GenerateStdHTTPServer(t *template.Template, operations []OperationDefinitio
 	return buf.String(), nil
 }
 
+// GenerateProblemDetails generates the RFC 9457 problem details type which
+// servers report request errors with, for `output-options.problem-details`.
+// The chi, gorilla and std-http wrappers already declare the typed parameter
+// errors; the other servers' wrappers only use them to report problem
+// details, so they're generated here.
+func GenerateProblemDetails(t *template.Template, opts Configuration) (string, error) {
+	templates := []string{"problem-details.tmpl"}
+	if !opts.Generate.ChiServer && !opts.Generate.GorillaServer && !opts.Generate.StdHTTPServer {
+		templates = append([]string{"param-errors.tmpl"}, templates...)
+	}
+	return GenerateTemplates(templates, t, nil)
+}
+
+// GenerateOperationRegistry generates the `Operations` table describing the
+// operations, and `OperationInfoFromContext`, which finds the entry put in the
+// request's context by the router wrappers.
+func GenerateOperationRegistry(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"operation-registry.tmpl"}, t, ops)
+}
+
+// GenerateCORS generates the `CORSOptions` which servers answer CORS
+// requests with, for `output-options.cors`. Each server's route registration
+// registers the preflight handlers.
+func GenerateCORS(t *template.Template) (string, error) {
+	return GenerateTemplates([]string{"cors.tmpl"}, t, nil)
+}
+
 func GenerateStrictServer(t *template.Template, serverTemplates map[string]*template.Template, operations []OperationDefinition, opts Configuration) (string, error) {
 
 	// Each strict framework renders its interface + glue templates against a
@@ -2620,7 +2670,14 @@ func GenerateStrictServer(t *template.Template, serverTemplates map[string]*temp
 	if chosen == nil {
 		return "", nil
 	}
-	return GenerateTemplates([]string{chosen.interfaceTmpl, chosen.glueTmpl}, chosen.tree, operations)
+	templates := []string{chosen.interfaceTmpl, chosen.glueTmpl}
+	if opts.OutputOptions.StrictErrorMapping {
+		templates = append(templates, "strict/strict-errors.tmpl")
+	}
+	if opts.OutputOptions.StrictContentNegotiation {
+		templates = append(templates, "strict/strict-negotiation.tmpl")
+	}
+	return GenerateTemplates(templates, chosen.tree, operations)
 }
 
 func GenerateStrictResponses(t *template.Template, responses []ResponseDefinition) (string, error) {
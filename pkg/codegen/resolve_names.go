@@ -18,6 +18,19 @@ type ResolvedName struct {
 // ResolveNames takes the gathered schemas and assigns unique Go type names to each.
 // It returns a map from the schema's path string to the resolved Go type name.
 func ResolveNames(schemas []*GatheredSchema) map[string]string {
+	candidates := resolveNames(schemas)
+
+	// Step 3: Build the result map
+	result := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		result[c.Schema.Path.String()] = c.GoName
+	}
+	return result
+}
+
+// resolveNames returns the resolved names of the gathered schemas, in their
+// order, with the candidate names they were resolved from.
+func resolveNames(schemas []*GatheredSchema) []*ResolvedName {
 	// Step 1: Generate candidate names for all schemas
 	candidates := make([]*ResolvedName, len(schemas))
 	for i, s := range schemas {
@@ -32,13 +45,7 @@ func ResolveNames(schemas []*GatheredSchema) map[string]string {
 
 	// Step 2: Resolve collisions iteratively
 	resolveCollisions(candidates)
-
-	// Step 3: Build the result map
-	result := make(map[string]string, len(candidates))
-	for _, c := range candidates {
-		result[c.Schema.Path.String()] = c.GoName
-	}
-	return result
+	return candidates
 }
 
 // generateCandidateName produces an initial Go type name candidate based on
@@ -0,0 +1,29 @@
+package codegen
+
+import "fmt"
+
+// Tags returns the operation's tags.
+func (o OperationDefinition) Tags() []string {
+	if o.Spec == nil {
+		return nil
+	}
+	return o.Spec.Tags
+}
+
+// MiddlewareNames returns the names of the middlewares which the operation's
+// `x-oapi-codegen-middlewares` extension applies to it, with
+// output-options.route-middlewares.
+func (o OperationDefinition) MiddlewareNames() ([]string, error) {
+	if o.Spec == nil {
+		return nil, nil
+	}
+	extension, ok := o.Spec.Extensions[extOapiCodegenMiddlewares]
+	if !ok {
+		return nil, nil
+	}
+	names, err := extParseOapiCodegenMiddlewares(extension)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q of operation %s: %w", extOapiCodegenMiddlewares, o.OperationId, err)
+	}
+	return names, nil
+}
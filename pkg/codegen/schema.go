@@ -138,8 +138,11 @@ type Property struct {
 	ReadOnly      bool
 	WriteOnly     bool
 	NeedsFormTag  bool
-	Extensions    map[string]any
-	Deprecated    bool
+	// Parameter is set for the fields of Params structs, which are bound to
+	// pointers, so aren't wrapped in `nullable.Nullable[T]` when nullable.
+	Parameter  bool
+	Extensions map[string]any
+	Deprecated bool
 }
 
 func (p Property) GoFieldName() string {
@@ -165,9 +168,12 @@ func (p Property) GoFieldName() string {
 
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
-	if globalState.options.OutputOptions.NullableType && p.Nullable {
+	if globalState.options.OutputOptions.NullableType && p.Nullable && !p.Parameter {
 		return "nullable.Nullable[" + typeDef + "]"
 	}
+	if p.IsOptionalType() {
+		return "Optional[" + typeDef + "]"
+	}
 	if !p.Schema.SkipOptionalPointer &&
 		(!p.Required || p.Nullable ||
 			(p.ReadOnly && (!p.Required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
@@ -181,13 +187,25 @@ func (p Property) GoTypeDef() string {
 // RequiresNilCheck indicates whether the generated property should have a nil check performed on it before other checks.
 // This should be used in templates when performing `nil` checks, but NOT when i.e. determining if there should be an optional pointer given to the type - in that case, use `HasOptionalPointer`
 func (p Property) RequiresNilCheck() bool {
+	if p.IsOptionalType() {
+		return false
+	}
 	return p.ZeroValueIsNil() || p.HasOptionalPointer()
 }
 
 // HasOptionalPointer indicates whether the generated property has an optional pointer associated with it.
 // This takes into account the `x-go-type-skip-optional-pointer` extension, allowing a parameter definition to control whether the pointer should be skipped.
 func (p Property) HasOptionalPointer() bool {
-	return !p.Required && !p.Schema.SkipOptionalPointer
+	return !p.Required && !p.Schema.SkipOptionalPointer && !p.IsOptionalType()
+}
+
+// IsOptionalType indicates whether the generated property is wrapped in the
+// generated `Optional[T]` type, which `output-options.optional-type` uses in
+// place of the optional pointer. Templates should check presence with
+// `IsSet()` rather than a `nil` check when this is true.
+func (p Property) IsOptionalType() bool {
+	return globalState.options.OutputOptions.OptionalType &&
+		!p.Required && !p.Nullable && !p.Schema.SkipOptionalPointer
 }
 
 // IsPointer reports whether the generated Go field for this property is
@@ -1021,6 +1039,8 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				return Schema{}, fmt.Errorf("error turning '%s: %v' into string",
 					extPropGoType, extension)
 			}
+		} else if spec, ok := globalState.typeMappingOverrides[sref]; ok {
+			refType = resolveTypeSpec(spec)
 		} else {
 			// Convert the reference path to Go type
 			var err error
@@ -1060,11 +1080,25 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 		return outSchema, nil
 	}
 
+	// A path-scoped type-mapping override, or a type-mapping for the format
+	// of an array or object, overrides the schema in the same way as
+	// x-go-type.
+	spec, ok, err := schemaTypeMapping(sref)
+	if err != nil {
+		return outSchema, err
+	}
+	if ok {
+		outSchema.GoType = resolveTypeSpec(spec)
+		outSchema.DefineViaAlias = true
+
+		return outSchema, nil
+	}
+
 	// AllOf is interesting, and useful. It's the union of a number of other
 	// schemas. A common usage is to create a union of an object with an ID,
 	// so that in a RESTful paradigm, the Create operation can return
 	// (object, id), so that other operations can refer to (id)
-	if schema.AllOf != nil {
+	if len(schema.AllOf) != 0 {
 		var mergedSchema Schema
 		var err error
 		// Behavior is gated on Compatibility.OldAllOfSiblingMerging:
@@ -1474,7 +1508,10 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 // oapiSchemaToGoType converts an OpenApi schema into a Go type definition for
 // all non-object types.
 func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schema) error {
-	f := schema.Format
+	f, err := typeMappingFormat(schema)
+	if err != nil {
+		return err
+	}
 	// In OpenAPI 3.1, `type` may be a multi-element array including "null"
 	// to express nullability. The dispatch below uses `*Types.Is("...")`,
 	// which only matches single-element type slices. Strip "null" up front
@@ -1536,15 +1573,15 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 
 	} else if t.Is("integer") {
 		spec := globalState.typeMapping.Integer.Resolve(f)
-		outSchema.GoType = spec.Type
+		outSchema.GoType = resolveTypeSpec(spec)
 		outSchema.DefineViaAlias = true
 	} else if t.Is("number") {
 		spec := globalState.typeMapping.Number.Resolve(f)
-		outSchema.GoType = spec.Type
+		outSchema.GoType = resolveTypeSpec(spec)
 		outSchema.DefineViaAlias = true
 	} else if t.Is("boolean") {
 		spec := globalState.typeMapping.Boolean.Resolve(f)
-		outSchema.GoType = spec.Type
+		outSchema.GoType = resolveTypeSpec(spec)
 		outSchema.DefineViaAlias = true
 	} else if t.Is("string") {
 		// OpenAPI 3.1: `contentMediaType` and `contentEncoding` are the
@@ -1590,7 +1627,7 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 			}
 		}
 		spec := globalState.typeMapping.String.Resolve(resolvedFormat)
-		outSchema.GoType = spec.Type
+		outSchema.GoType = resolveTypeSpec(spec)
 		// Preserve special behaviors for specific types
 		if outSchema.GoType == "[]byte" {
 			setSkipOptionalPointerForContainerType(outSchema)
@@ -1692,10 +1729,11 @@ func GenFieldsFromProperties(props []Property) []string {
 		// serialize their key per JSON Schema `required` semantics. The
 		// nullable-type option is the exception — nullable.Nullable[T]
 		// distinguishes absent from null itself and relies on omitempty for
-		// the absent case. Issue #2503.
+		// the absent case. Issue #2503. Parameters have no `null` to encode,
+		// so they're unaffected.
 		omitEmpty := !p.Nullable && shouldOmitEmpty
 
-		if p.Nullable && globalState.options.OutputOptions.NullableType {
+		if p.Nullable && (globalState.options.OutputOptions.NullableType || p.Parameter) {
 			omitEmpty = shouldOmitEmpty
 		}
 
@@ -1706,6 +1744,13 @@ func GenFieldsFromProperties(props []Property) []string {
 			omitZero = true
 		}
 
+		// Optional[T] is a struct, so `omitempty` never omits it; its
+		// IsZero method reports whether the value was set instead.
+		if p.IsOptionalType() {
+			omitEmpty = false
+			omitZero = true
+		}
+
 		// Support x-omitempty and x-omitzero
 		if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
 			if xValue, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
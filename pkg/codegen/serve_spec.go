@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// defaultServeSpecJSONPath is the path of the spec as JSON, with
+	// output-options.serve-spec.
+	defaultServeSpecJSONPath = "/openapi.json"
+	// defaultServeSpecYAMLPath is the path of the spec as YAML, with
+	// output-options.serve-spec.
+	defaultServeSpecYAMLPath = "/openapi.yaml"
+)
+
+// SpecRoute is the route of a handler serving the spec, with
+// output-options.serve-spec.
+type SpecRoute struct {
+	// Path is the path of the spec, such as `/openapi.json`.
+	Path string
+	// AsYAML is whether the spec is served as YAML rather than JSON.
+	AsYAML bool
+}
+
+// specRoutes returns the routes of the spec handlers which o configures.
+func specRoutes(o *ServeSpecOptions) []SpecRoute {
+	return []SpecRoute{
+		{Path: o.JSONPath},
+		{Path: o.YAMLPath, AsYAML: true},
+	}
+}
+
+// GenerateServeSpec generates the `SpecOptions`, and the encoding of the
+// embedded spec, which the servers' spec handlers share.
+func GenerateServeSpec(t *template.Template) (string, error) {
+	return GenerateTemplates([]string{"serve-spec.tmpl"}, t, nil)
+}
+
+// publicSpec returns a copy of swagger without the operations whose
+// `x-internal` extension is true, the paths which are left without
+// operations, and the components which are then unused.
+func publicSpec(swagger *openapi3.T) (*openapi3.T, error) {
+	data, err := swagger.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling swagger: %w", err)
+	}
+	public, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("error copying swagger: %w", err)
+	}
+	if public.Paths == nil {
+		return public, nil
+	}
+
+	for path, pathItem := range public.Paths.Map() {
+		for method, op := range pathItem.Operations() {
+			internal, err := operationIsInternal(op)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q of operation %s %s: %w", extInternal, method, path, err)
+			}
+			if internal {
+				pathItem.SetOperation(method, nil)
+			}
+		}
+		if len(pathItem.Operations()) == 0 {
+			public.Paths.Delete(path)
+		}
+	}
+	pruneUnusedComponents(public)
+	return public, nil
+}
+
+// operationIsInternal reports whether the operation's `x-internal` extension
+// is true.
+func operationIsInternal(op *openapi3.Operation) (bool, error) {
+	extension, ok := op.Extensions[extInternal]
+	if !ok {
+		return false, nil
+	}
+	return extParseInternal(extension)
+}
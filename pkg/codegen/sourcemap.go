@@ -0,0 +1,401 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SourceMap maps the identifiers of generated code to the parts of the spec
+// they're generated from.
+type SourceMap struct {
+	// Identifiers are the identifiers of the generated code which are
+	// generated from the spec, in the order they're declared. The
+	// identifiers which aren't, such as Client, aren't mapped.
+	Identifiers []SourceMapEntry `json:"identifiers"`
+}
+
+// SourceMapEntry locates the part of the spec an identifier of generated
+// code is generated from.
+type SourceMapEntry struct {
+	// Identifier is the identifier, qualified by its type for fields and
+	// methods, such as `Pet.Name`.
+	Identifier string `json:"identifier"`
+	// Kind is the kind of declaration of the identifier: "type", "field",
+	// "method", "func", "const" or "var".
+	Kind string `json:"kind"`
+	// Pointer is the JSON pointer of the part of the spec, such as
+	// `/components/schemas/Pet`.
+	Pointer string `json:"pointer,omitempty"`
+	// File, Line and Column are where the part of the spec is, when they're
+	// known.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	// Rule describes how the identifier is named after the part of the
+	// spec.
+	Rule string `json:"rule"`
+}
+
+// String returns the entry as `identifier (kind): pointer at
+// file:line:column, rule`.
+func (e SourceMapEntry) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s): %s", e.Identifier, e.Kind, e.Pointer)
+	if e.File != "" {
+		fmt.Fprintf(&b, " at %s:%d:%d", e.File, e.Line, e.Column)
+	}
+	fmt.Fprintf(&b, ", %s", e.Rule)
+	return b.String()
+}
+
+// Lookup returns the entries of identifier, which may be a qualified field
+// or method such as `Pet.Name`, or the name of the fields and methods of any
+// type, such as `Name`.
+func (m *SourceMap) Lookup(identifier string) []SourceMapEntry {
+	var entries []SourceMapEntry
+	for _, e := range m.Identifiers {
+		if e.Identifier == identifier || strings.HasSuffix(e.Identifier, "."+identifier) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// GenerateResult is the code generated by GenerateWithSourceMap, along with
+// its diagnostics and its source map.
+type GenerateResult struct {
+	Code        string
+	Diagnostics []Diagnostic
+	// SourceMap is nil when generation fails.
+	SourceMap *SourceMap
+}
+
+// GenerateWithSourceMap generates code from spec with opts, as
+// GenerateWithDiagnostics does, and maps the identifiers of the generated
+// code to the parts of the spec they're generated from.
+//
+// The files and lines of the parts of the spec are only known when spec was
+// loaded with its origins, as util.LoadSwagger does.
+func GenerateWithSourceMap(spec *openapi3.T, opts Configuration) (GenerateResult, error) {
+	code, diagnostics, err := GenerateWithDiagnostics(spec, opts)
+	result := GenerateResult{Code: code, Diagnostics: diagnostics}
+	if err != nil {
+		return result, err
+	}
+	result.SourceMap, err = newSourceMap(code)
+	return result, err
+}
+
+// sourceLocation is the part of the spec an identifier is generated from.
+type sourceLocation struct {
+	pointer string
+	origin  *openapi3.Origin
+	rule    string
+}
+
+// sourceMapper locates the identifiers of the code generated last in the
+// spec it was generated from.
+type sourceMapper struct {
+	pointers *specPointers
+	types    map[string]TypeDefinition
+	// enumTypes maps the constants of enum values to their types.
+	enumTypes map[string]TypeDefinition
+}
+
+// newSourceMap returns the source map of code, which was generated last.
+func newSourceMap(code string) (*SourceMap, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+
+	m := &sourceMapper{
+		pointers:  newSpecPointers(globalState.spec),
+		types:     map[string]TypeDefinition{},
+		enumTypes: map[string]TypeDefinition{},
+	}
+	var addTypes func(types []TypeDefinition)
+	addTypes = func(types []TypeDefinition) {
+		for _, td := range types {
+			if _, ok := m.types[td.TypeName]; !ok {
+				m.types[td.TypeName] = td
+			}
+			for name := range td.Schema.EnumValues {
+				m.enumTypes[name] = td
+			}
+			addTypes(td.Schema.AdditionalTypes)
+			for _, p := range td.Schema.Properties {
+				addTypes(p.Schema.AdditionalTypes)
+			}
+		}
+	}
+	addTypes(globalState.emittedTypes)
+
+	sourceMap := &SourceMap{Identifiers: []SourceMapEntry{}}
+	add := func(identifier, kind string, location *sourceLocation) {
+		if location == nil {
+			return
+		}
+		entry := SourceMapEntry{Identifier: identifier, Kind: kind, Pointer: location.pointer, Rule: location.rule}
+		if location.origin != nil && location.origin.Key != nil {
+			entry.File, entry.Line, entry.Column = location.origin.Key.File, location.origin.Key.Line, location.origin.Key.Column
+		}
+		sourceMap.Identifiers = append(sourceMap.Identifiers, entry)
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				add(decl.Name.Name, "func", m.locateOperationIdentifier(decl.Name.Name))
+				continue
+			}
+			if receiver := receiverName(decl.Recv); receiver != "" {
+				add(receiver+"."+decl.Name.Name, "method", m.locateOperationIdentifier(decl.Name.Name))
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					name := spec.Name.Name
+					add(name, "type", m.locateType(name))
+					switch typ := spec.Type.(type) {
+					case *ast.StructType:
+						for _, field := range typ.Fields.List {
+							for _, fieldName := range field.Names {
+								add(name+"."+fieldName.Name, "field", m.locateField(name, fieldName.Name))
+							}
+						}
+					case *ast.InterfaceType:
+						for _, method := range typ.Methods.List {
+							for _, methodName := range method.Names {
+								add(name+"."+methodName.Name, "method", m.locateOperationIdentifier(methodName.Name))
+							}
+						}
+					}
+				case *ast.ValueSpec:
+					kind := "var"
+					if decl.Tok == token.CONST {
+						kind = "const"
+					}
+					for _, valueName := range spec.Names {
+						add(valueName.Name, kind, m.locateValue(valueName.Name))
+					}
+				}
+			}
+		}
+	}
+	return sourceMap, nil
+}
+
+// receiverName returns the name of the type of the receiver of a method.
+func receiverName(recv *ast.FieldList) string {
+	if len(recv.List) != 1 {
+		return ""
+	}
+	typ := recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// schemaLocation returns the JSON pointer and the origin of the schema of
+// td, or "" if it isn't known.
+func (m *sourceMapper) schemaLocation(td TypeDefinition) (string, *openapi3.Origin) {
+	if s := td.Schema.OAPISchema; s != nil {
+		if pointer, ok := m.pointers.schemas[s]; ok {
+			return pointer, s.Origin
+		}
+	}
+	// The schemas of components merged with allOf aren't the schemas of the
+	// spec.
+	if spec := globalState.spec; spec != nil && spec.Components != nil {
+		if ref, ok := spec.Components.Schemas[td.JsonName]; ok && ref.Value != nil {
+			return jsonPointer("components", "schemas", td.JsonName), ref.Value.Origin
+		}
+	}
+	return "", nil
+}
+
+// locateType returns the location of the type name.
+func (m *sourceMapper) locateType(name string) *sourceLocation {
+	if td, ok := m.types[name]; ok {
+		if pointer, origin := m.schemaLocation(td); pointer != "" {
+			return &sourceLocation{pointer: pointer, origin: origin, rule: m.typeRule(td, pointer)}
+		}
+	}
+	return m.locateOperationIdentifier(name)
+}
+
+// typeRule describes how the type of td, whose schema is at pointer, is
+// named.
+func (m *sourceMapper) typeRule(td TypeDefinition, pointer string) string {
+	if renamed, ok := globalState.renamedTypes[td.TypeName]; ok {
+		return fmt.Sprintf("renamed from %s, which is also the name of another type, by output-options.resolve-type-name-collisions", renamed.Candidate)
+	}
+	if s := td.Schema.OAPISchema; s != nil {
+		if _, ok := s.Extensions[extGoName]; ok {
+			return "named by its x-go-name"
+		}
+		if _, ok := s.Extensions[extGoTypeName]; ok {
+			return "named by its x-go-type-name"
+		}
+	}
+	// The types of components are named after them, even when their schemas
+	// are in their content, as the schemas of responses are.
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	if len(tokens) >= 3 && tokens[0] == "components" && tokens[2] == escapeJSONPointer(td.JsonName) {
+		return fmt.Sprintf("named after its name %q in components/%s", td.JsonName, tokens[1])
+	}
+	if globalState.options.OutputOptions.GenerateTypesForAnonymousSchemas {
+		return "named after the location of its inline schema, which output-options.generate-types-for-anonymous-schemas hoists"
+	}
+	return "named after the location of its inline schema"
+}
+
+// locateField returns the location of the field of the type typeName.
+func (m *sourceMapper) locateField(typeName, fieldName string) *sourceLocation {
+	if td, ok := m.types[typeName]; ok {
+		if pointer, _ := m.schemaLocation(td); pointer != "" {
+			for _, p := range td.Schema.Properties {
+				if p.GoFieldName() == fieldName {
+					return m.propertyLocation(td, p)
+				}
+			}
+			return nil
+		}
+	}
+
+	// The fields of the types of operations, such as their Params, are
+	// their parameters.
+	op := m.operation(typeName)
+	if op == nil {
+		return nil
+	}
+	for _, params := range [][]ParameterDefinition{op.PathParams, op.QueryParams, op.HeaderParams, op.CookieParams} {
+		for _, pd := range params {
+			if pd.GoName() != fieldName || pd.Spec == nil {
+				continue
+			}
+			location := &sourceLocation{
+				pointer: m.parameterPointer(op, pd.Spec),
+				origin:  pd.Spec.Origin,
+				rule:    fmt.Sprintf("named after the %s parameter %q", pd.In, pd.ParamName),
+			}
+			if _, ok := pd.Spec.Extensions[extGoName]; ok {
+				location.rule = "named by its x-go-name"
+			}
+			return location
+		}
+	}
+	return nil
+}
+
+// propertyLocation returns the location of the property p of the schema of
+// td, or nil if it isn't known, as it isn't for the properties of schemas
+// merged from allOf, which are located where they're declared instead.
+func (m *sourceMapper) propertyLocation(td TypeDefinition, p Property) *sourceLocation {
+	location := &sourceLocation{rule: fmt.Sprintf("named after the property %q", p.JsonFieldName)}
+	if _, ok := p.Extensions[extGoName]; ok {
+		location.rule = "named by its x-go-name"
+	}
+
+	parent := td.Schema.OAPISchema
+	if pointer, ok := m.pointers.schemas[parent]; ok && parent.Properties[p.JsonFieldName] != nil {
+		ref := parent.Properties[p.JsonFieldName]
+		location.pointer, location.origin = pointer+jsonPointer("properties", p.JsonFieldName), ref.Origin
+		if ref.Ref == "" && ref.Value != nil {
+			location.origin = ref.Value.Origin
+		}
+		return location
+	}
+	if pointer, ok := m.pointers.schemas[p.Schema.OAPISchema]; ok && strings.HasSuffix(pointer, jsonPointer("properties", p.JsonFieldName)) {
+		location.pointer, location.origin = pointer, p.Schema.OAPISchema.Origin
+		return location
+	}
+	return nil
+}
+
+// parameterPointer returns the JSON pointer of the parameter of op, which is
+// declared by the operation, or its path item.
+func (m *sourceMapper) parameterPointer(op *OperationDefinition, parameter *openapi3.Parameter) string {
+	opPointer := m.pointers.operations[op.Spec]
+	for i, ref := range op.Spec.Parameters {
+		if ref.Value == parameter {
+			return opPointer + jsonPointer("parameters", strconv.Itoa(i))
+		}
+	}
+	if pathItem := globalState.spec.Paths.Value(op.Path); pathItem != nil && !op.IsWebhook && !op.IsCallback {
+		for i, ref := range pathItem.Parameters {
+			if ref.Value == parameter {
+				return jsonPointer("paths", op.Path, "parameters", strconv.Itoa(i))
+			}
+		}
+	}
+	return opPointer
+}
+
+// locateValue returns the location of the constant or the variable name.
+func (m *sourceMapper) locateValue(name string) *sourceLocation {
+	if td, ok := m.enumTypes[name]; ok {
+		if pointer, origin := m.schemaLocation(td); pointer != "" {
+			return &sourceLocation{
+				pointer: pointer,
+				origin:  origin,
+				rule:    fmt.Sprintf("named after the enum value %q of %s", td.Schema.EnumValues[name], td.TypeName),
+			}
+		}
+	}
+	return m.locateOperationIdentifier(name)
+}
+
+// locateOperationIdentifier returns the location of the operation an
+// identifier is named after, such as ListPets, ListPetsParams or
+// NewListPetsRequest.
+func (m *sourceMapper) locateOperationIdentifier(name string) *sourceLocation {
+	op := m.operation(name)
+	if op == nil {
+		return nil
+	}
+	location := &sourceLocation{
+		pointer: m.pointers.operations[op.Spec],
+		origin:  op.Spec.Origin,
+		rule:    fmt.Sprintf("named after the operationId %q", op.SpecOperationId),
+	}
+	if op.SpecOperationId == "" {
+		location.rule = fmt.Sprintf("named after the method and path of %s %s, which has no operationId", op.Method, op.Path)
+	}
+	return location
+}
+
+// operation returns the operation an identifier is named after, which is the
+// operation whose name it starts with, after any New or Parse prefix,
+// followed by another word, or nil if there's none.
+func (m *sourceMapper) operation(name string) *OperationDefinition {
+	var found *OperationDefinition
+	for i := range globalState.emittedOperations {
+		op := &globalState.emittedOperations[i]
+		if op.Spec == nil || op.OperationId == "" || (found != nil && len(found.OperationId) >= len(op.OperationId)) {
+			continue
+		}
+		for _, prefix := range []string{"", "New", "Parse"} {
+			rest, ok := strings.CutPrefix(name, prefix+op.OperationId)
+			if ok && (rest == "" || unicode.IsUpper([]rune(rest)[0])) {
+				found = op
+				break
+			}
+		}
+	}
+	return found
+}
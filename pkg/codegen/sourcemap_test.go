@@ -0,0 +1,73 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestGenerateWithSourceMap(t *testing.T) {
+	const specPath = "test_specs/sourcemap.yaml"
+	spec, err := util.LoadSwagger(specPath)
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "sourcemap",
+		Generate:    GenerateOptions{Models: true, Client: true},
+	}
+	opts.OutputOptions.SkipPrune = true
+	opts.OutputOptions.ResolveTypeNameCollisions = true
+	opts.OutputOptions.GenerateTypesForAnonymousSchemas = true
+	result, err := GenerateWithSourceMap(spec, opts)
+	require.NoError(t, err)
+	require.NotNil(t, result.SourceMap)
+
+	entries := map[string]SourceMapEntry{}
+	for _, e := range result.SourceMap.Identifiers {
+		entries[e.Identifier] = e
+	}
+	// Client isn't generated from the spec.
+	assert.NotContains(t, entries, "Client")
+
+	assert.Equal(t, SourceMapEntry{
+		Identifier: "Pet",
+		Kind:       "type",
+		Pointer:    "/components/schemas/Pet",
+		File:       specPath,
+		Line:       33,
+		Column:     5,
+		Rule:       `named after its name "Pet" in components/schemas`,
+	}, entries["Pet"])
+
+	assert.Equal(t, "/components/responses/Pet/content/application~1json/schema", entries["PetResponse"].Pointer)
+	assert.Equal(t, "renamed from Pet, which is also the name of another type, by output-options.resolve-type-name-collisions", entries["PetResponse"].Rule)
+
+	assert.Equal(t, "/components/schemas/Pet/properties/petName", entries["Pet.Called"].Pointer)
+	assert.Equal(t, 39, entries["Pet.Called"].Line)
+	assert.Equal(t, "named by its x-go-name", entries["Pet.Called"].Rule)
+
+	assert.Equal(t, "const", entries["Cat"].Kind)
+	assert.Equal(t, "/components/schemas/Pet/properties/kind", entries["Cat"].Pointer)
+
+	assert.Equal(t, "/components/responses/Pet/content/application~1json/schema/properties/owner", entries["Pet_Owner"].Pointer)
+	assert.Contains(t, entries["Pet_Owner"].Rule, "generate-types-for-anonymous-schemas")
+
+	assert.Equal(t, "/paths/~1pets~1{petId}/get/parameters/0", entries["GetPetParams.Verbose"].Pointer)
+	assert.Equal(t, `named after the query parameter "verbose"`, entries["GetPetParams.Verbose"].Rule)
+
+	assert.Equal(t, SourceMapEntry{
+		Identifier: "Client.GetPet",
+		Kind:       "method",
+		Pointer:    "/paths/~1pets~1{petId}/get",
+		File:       specPath,
+		Line:       10,
+		Column:     5,
+		Rule:       `named after the operationId "getPet"`,
+	}, entries["Client.GetPet"])
+	assert.Equal(t, "/paths/~1pets~1{petId}/get", entries["NewGetPetRequest"].Pointer)
+
+	assert.Len(t, result.SourceMap.Lookup("GetPet"), 2)
+}
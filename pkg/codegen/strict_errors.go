@@ -0,0 +1,89 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StrictErrorResponseDefinition describes a declared error response of an
+// operation, which a strict server sends in place of an error returned by the
+// handler, with output-options.strict-error-mapping.
+type StrictErrorResponseDefinition struct {
+	// StatusCode is the status code the response is declared with, such as
+	// `404`, `4XX` or `default`.
+	StatusCode string
+	// Condition is the boolean expression, in terms of `status`, which
+	// selects the response. It's empty for the `default` response.
+	Condition string
+	// BodyType is the Go type of the response's JSON body.
+	BodyType string
+	// Response is the expression building the response object, in terms of
+	// `body` and `status`.
+	Response string
+}
+
+// StrictErrorResponses returns the declared error responses which a strict
+// server can send in place of an error: the `4XX` and `5XX` responses, ranges
+// of them, and the `default` response, which have a JSON body and no headers.
+// Responses with exact status codes come first, then ranges, then `default`,
+// so that the most specific one is chosen.
+func (o OperationDefinition) StrictErrorResponses() []StrictErrorResponseDefinition {
+	var exact, ranges, fallback []StrictErrorResponseDefinition
+	for _, r := range o.Responses {
+		if len(r.Headers) != 0 || r.IsExternalRef() {
+			continue
+		}
+		code := strings.ToUpper(r.StatusCode)
+		var condition string
+		switch {
+		case code == "DEFAULT":
+		case len(code) == 3 && strings.HasSuffix(code, "XX") && code[0] >= '4' && code[0] <= '5':
+			from := int(code[0]-'0') * 100
+			condition = fmt.Sprintf("status >= %d && status < %d", from, from+100)
+		default:
+			status, err := strconv.Atoi(code)
+			if err != nil || status < 400 {
+				continue
+			}
+			condition = "status == " + code
+		}
+
+		// Only the first JSON representation of a response is sent.
+		var content *ResponseContentDefinition
+		for i, c := range r.Contents {
+			if c.IsJSON() && c.HasFixedContentType() {
+				content = &r.Contents[i]
+				break
+			}
+		}
+		if content == nil {
+			continue
+		}
+
+		def := StrictErrorResponseDefinition{
+			StatusCode: r.StatusCode,
+			Condition:  condition,
+			BodyType:   content.Schema.TypeDecl(),
+		}
+		typeName := o.OperationId + r.StatusCode + content.NameTagOrContentType() + "Response"
+		switch {
+		case !r.HasFixedStatusCode():
+			def.Response = typeName + "{Body: body, StatusCode: status}"
+		case r.IsRef():
+			def.Response = fmt.Sprintf("%s{%s%sResponse(body)}", typeName, UppercaseFirstCharacterWithPkgName(r.Ref), content.NameTagOrContentType())
+		default:
+			def.Response = typeName + "(body)"
+		}
+
+		switch {
+		case condition == "":
+			fallback = append(fallback, def)
+		case r.HasFixedStatusCode():
+			exact = append(exact, def)
+		default:
+			ranges = append(ranges, def)
+		}
+	}
+	return append(append(exact, ranges...), fallback...)
+}
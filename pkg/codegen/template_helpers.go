@@ -419,6 +419,8 @@ func httpMethodConstant(method string) string {
 		return "http.MethodOptions"
 	case "TRACE":
 		return "http.MethodTrace"
+	case "CONNECT":
+		return "http.MethodConnect"
 	default:
 		return fmt.Sprintf("%q", method)
 	}
@@ -492,6 +494,11 @@ var TemplateFunctions = template.FuncMap{
 	"toGoString":                 StringToGoString,
 	"toGoComment":                StringWithTypeNameToGoComment,
 	"unionTypes":                 unionTypesFragment,
+	"serverGroups":               serverGroups,
+	"clientGroups":               clientGroups,
+	"corsPaths":                  corsPaths,
+	"pathMethods":                pathMethods,
+	"specRoutes":                 specRoutes,
 
 	"genServerURLWithVariablesFunctionParams": genServerURLWithVariablesFunctionParams,
 	"httpMethodConstant":                      httpMethodConstant,
@@ -1,6 +1,13 @@
 package codegen
 
-import "maps"
+import (
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 // SimpleTypeSpec defines the Go type for an OpenAPI type/format combination,
 // along with any import required to use it.
@@ -16,21 +23,42 @@ type FormatMapping struct {
 	Formats map[string]SimpleTypeSpec `yaml:"formats,omitempty" json:"formats,omitempty"`
 }
 
+// TypeMappingOverride maps every schema whose location in the spec matches
+// Path to a Go type, regardless of its OpenAPI type and format.
+type TypeMappingOverride struct {
+	// Path is a JSON pointer to a schema, such as
+	// `#/components/schemas/Order/properties/total`. Each segment may use
+	// the wildcards supported by path.Match, so `*` matches exactly one
+	// segment, e.g. `#/components/schemas/*/properties/total`.
+	Path           string `yaml:"path" json:"path"`
+	SimpleTypeSpec `yaml:",inline"`
+}
+
 // TypeMapping defines the mapping from OpenAPI types to Go types.
 type TypeMapping struct {
 	Integer FormatMapping `yaml:"integer,omitempty" json:"integer"`
 	Number  FormatMapping `yaml:"number,omitempty" json:"number"`
 	Boolean FormatMapping `yaml:"boolean,omitempty" json:"boolean"`
 	String  FormatMapping `yaml:"string,omitempty" json:"string"`
+	// Array and Object have no mappings by default, so such schemas are
+	// generated as slices and structs unless a format is mapped here.
+	Array  FormatMapping `yaml:"array,omitempty" json:"array"`
+	Object FormatMapping `yaml:"object,omitempty" json:"object"`
+	// Overrides map schemas at specific locations in the spec, and take
+	// precedence over the type/format mappings above.
+	Overrides []TypeMappingOverride `yaml:"overrides,omitempty" json:"overrides,omitempty"`
 }
 
 // Merge returns a new TypeMapping with user overrides applied on top of base.
 func (base TypeMapping) Merge(user TypeMapping) TypeMapping {
 	return TypeMapping{
-		Integer: base.Integer.merge(user.Integer),
-		Number:  base.Number.merge(user.Number),
-		Boolean: base.Boolean.merge(user.Boolean),
-		String:  base.String.merge(user.String),
+		Integer:   base.Integer.merge(user.Integer),
+		Number:    base.Number.merge(user.Number),
+		Boolean:   base.Boolean.merge(user.Boolean),
+		String:    base.String.merge(user.String),
+		Array:     base.Array.merge(user.Array),
+		Object:    base.Object.merge(user.Object),
+		Overrides: append(append([]TypeMappingOverride{}, base.Overrides...), user.Overrides...),
 	}
 }
 
@@ -65,6 +93,176 @@ func (fm FormatMapping) Resolve(format string) SimpleTypeSpec {
 	return fm.Default
 }
 
+// typeMappingFormat returns the format used to look up a schema in the type
+// mapping, which is the `x-go-type-format` extension if set, and the
+// schema's `format` otherwise.
+func typeMappingFormat(schema *openapi3.Schema) (string, error) {
+	if extension, ok := schema.Extensions[extGoTypeFormat]; ok {
+		format, err := extString(extension)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q: %w", extGoTypeFormat, err)
+		}
+		return format, nil
+	}
+	return schema.Format, nil
+}
+
+// schemaTypeMapping returns the type mapped for a schema by a path-scoped
+// override, or for an array or object schema by its format. Other types
+// are mapped by oapiSchemaToGoType.
+func schemaTypeMapping(sref *openapi3.SchemaRef) (SimpleTypeSpec, bool, error) {
+	if spec, ok := globalState.typeMappingOverrides[sref]; ok {
+		return spec, true, nil
+	}
+
+	var fm FormatMapping
+	switch t := schemaPrimaryType(sref.Value.Type); {
+	case t.Is("array"):
+		fm = globalState.typeMapping.Array
+	case t.Is("object"):
+		fm = globalState.typeMapping.Object
+	default:
+		return SimpleTypeSpec{}, false, nil
+	}
+	format, err := typeMappingFormat(sref.Value)
+	if err != nil {
+		return SimpleTypeSpec{}, false, err
+	}
+	spec := fm.Resolve(format)
+	return spec, spec.Type != "", nil
+}
+
+// resolveTypeSpec returns the Go type for a resolved type mapping, recording
+// its import so that it's added to the generated code. Standard library
+// imports are left to goimports, which already resolves them.
+func resolveTypeSpec(spec SimpleTypeSpec) string {
+	if first, _, _ := strings.Cut(spec.Import, "/"); strings.Contains(first, ".") {
+		if globalState.typeMappingImports == nil {
+			globalState.typeMappingImports = map[string]goImport{}
+		}
+		globalState.typeMappingImports[spec.Import] = goImport{Path: spec.Import}
+	}
+	return spec.Type
+}
+
+// matchTypeMappingOverrides walks every schema reachable from the spec's
+// components and paths, returning the type spec of the first override whose
+// Path matches the schema's JSON pointer.
+func matchTypeMappingOverrides(spec *openapi3.T, overrides []TypeMappingOverride) (map[*openapi3.SchemaRef]SimpleTypeSpec, error) {
+	result := map[*openapi3.SchemaRef]SimpleTypeSpec{}
+	if len(overrides) == 0 || spec == nil {
+		return result, nil
+	}
+
+	patterns := make([]string, len(overrides))
+	for i, o := range overrides {
+		if o.Path == "" || o.Type == "" {
+			return nil, fmt.Errorf("type-mapping override %d must specify both path and type", i)
+		}
+		patterns[i] = strings.TrimPrefix(o.Path, "#")
+		if _, err := path.Match(patterns[i], ""); err != nil {
+			return nil, fmt.Errorf("invalid type-mapping override path %q: %w", o.Path, err)
+		}
+	}
+
+	var walk func(sref *openapi3.SchemaRef, pointer string)
+	walk = func(sref *openapi3.SchemaRef, pointer string) {
+		if sref == nil {
+			return
+		}
+		for i, pattern := range patterns {
+			if ok, _ := path.Match(pattern, pointer); ok {
+				if _, seen := result[sref]; !seen {
+					result[sref] = overrides[i].SimpleTypeSpec
+				}
+				break
+			}
+		}
+		// Referenced schemas are visited at their own location.
+		if sref.Ref != "" || sref.Value == nil {
+			return
+		}
+		schema := sref.Value
+		for name, p := range schema.Properties {
+			walk(p, pointer+"/properties/"+escapeJSONPointer(name))
+		}
+		walk(schema.Items, pointer+"/items")
+		if schema.AdditionalProperties.Schema != nil {
+			walk(schema.AdditionalProperties.Schema, pointer+"/additionalProperties")
+		}
+		for key, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "anyOf": schema.AnyOf, "oneOf": schema.OneOf} {
+			for i, r := range refs {
+				walk(r, fmt.Sprintf("%s/%s/%d", pointer, key, i))
+			}
+		}
+		walk(schema.Not, pointer+"/not")
+	}
+	walkContent := func(content openapi3.Content, pointer string) {
+		for mediaType, mt := range content {
+			if mt != nil {
+				walk(mt.Schema, pointer+"/content/"+escapeJSONPointer(mediaType)+"/schema")
+			}
+		}
+	}
+	walkParameters := func(params openapi3.Parameters, pointer string) {
+		for i, p := range params {
+			if p != nil && p.Ref == "" && p.Value != nil {
+				walk(p.Value.Schema, fmt.Sprintf("%s/%d/schema", pointer, i))
+			}
+		}
+	}
+	walkResponses := func(responses map[string]*openapi3.ResponseRef, pointer string) {
+		for code, r := range responses {
+			if r != nil && r.Ref == "" && r.Value != nil {
+				walkContent(r.Value.Content, pointer+"/"+escapeJSONPointer(code))
+			}
+		}
+	}
+
+	if c := spec.Components; c != nil {
+		for name, s := range c.Schemas {
+			walk(s, "/components/schemas/"+escapeJSONPointer(name))
+		}
+		for name, p := range c.Parameters {
+			if p != nil && p.Value != nil {
+				walk(p.Value.Schema, "/components/parameters/"+escapeJSONPointer(name)+"/schema")
+			}
+		}
+		for name, b := range c.RequestBodies {
+			if b != nil && b.Value != nil {
+				walkContent(b.Value.Content, "/components/requestBodies/"+escapeJSONPointer(name))
+			}
+		}
+		walkResponses(c.Responses, "/components/responses")
+	}
+	if spec.Paths != nil {
+		for pathName, item := range spec.Paths.Map() {
+			pointer := "/paths/" + escapeJSONPointer(pathName)
+			walkParameters(item.Parameters, pointer+"/parameters")
+			for method, op := range item.Operations() {
+				if op == nil {
+					continue
+				}
+				opPointer := pointer + "/" + strings.ToLower(method)
+				walkParameters(op.Parameters, opPointer+"/parameters")
+				if op.RequestBody != nil && op.RequestBody.Ref == "" && op.RequestBody.Value != nil {
+					walkContent(op.RequestBody.Value.Content, opPointer+"/requestBody")
+				}
+				if op.Responses != nil {
+					walkResponses(op.Responses.Map(), opPointer+"/responses")
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// escapeJSONPointer escapes a single JSON pointer reference token, as
+// defined by RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
 // DefaultTypeMapping provides the default OpenAPI type/format to Go type mappings.
 var DefaultTypeMapping = TypeMapping{
 	Integer: FormatMapping{
@@ -106,3 +304,27 @@ var DefaultTypeMapping = TypeMapping{
 		},
 	},
 }
+
+// formatTypesImport is the import path of the types which FormatTypeMapping
+// maps to.
+const formatTypesImport = "github.com/oapi-codegen/oapi-codegen/v2/pkg/types"
+
+// FormatTypeMapping maps the formats which plain Go types can't represent
+// without losing precision to the types of the pkg/types package. It's merged
+// on top of DefaultTypeMapping with output-options.format-types, and beneath
+// the user's output-options.type-mapping.
+var FormatTypeMapping = TypeMapping{
+	Number: FormatMapping{
+		Formats: map[string]SimpleTypeSpec{
+			"decimal": {Type: "types.DecimalNumber", Import: formatTypesImport},
+		},
+	},
+	String: FormatMapping{
+		Formats: map[string]SimpleTypeSpec{
+			"time":    {Type: "types.TimeOfDay", Import: formatTypesImport},
+			"decimal": {Type: "types.Decimal", Import: formatTypesImport},
+			"int64":   {Type: "types.StringInt64", Import: formatTypesImport},
+			"uint64":  {Type: "types.StringUint64", Import: formatTypesImport},
+		},
+	},
+}
@@ -141,3 +141,191 @@ components:
 	assert.Contains(t, code, "Backoff string `json:\"backoff\"`")
 	assert.NotContains(t, code, "openapi_types.Duration")
 }
+
+func TestArrayObjectAndOverrideTypeMapping(t *testing.T) {
+	const spec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Orders
+paths:
+  /orders/{id}:
+    get:
+      operationId: getOrder
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: An order
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Order"
+components:
+  schemas:
+    Money:
+      type: object
+      format: money
+      properties:
+        amount:
+          type: string
+    Order:
+      type: object
+      required: [total, discount]
+      properties:
+        total:
+          $ref: "#/components/schemas/Money"
+        discount:
+          type: object
+          format: money
+        tags:
+          type: array
+          x-go-type-format: set
+          items:
+            type: string
+        lines:
+          type: array
+          items:
+            type: string
+        note:
+          type: string
+    Invoice:
+      type: object
+      properties:
+        note:
+          type: string
+`
+	loader := openapi3.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+			TypeMapping: &TypeMapping{
+				Object: FormatMapping{
+					Formats: map[string]SimpleTypeSpec{
+						"money": {Type: "decimal.Decimal", Import: "github.com/shopspring/decimal"},
+					},
+				},
+				Array: FormatMapping{
+					Formats: map[string]SimpleTypeSpec{
+						"set": {Type: "sets.Set[string]", Import: "example.com/sets"},
+					},
+				},
+				Overrides: []TypeMappingOverride{
+					{Path: "#/components/schemas/*/properties/note", SimpleTypeSpec: SimpleTypeSpec{Type: "Note"}},
+					{Path: "#/paths/~1orders~1{id}/get/parameters/0/schema", SimpleTypeSpec: SimpleTypeSpec{Type: "OrderID"}},
+				},
+			},
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "type Money = decimal.Decimal")
+	assert.Contains(t, code, "Total    Money             `json:\"total\"`")
+	assert.Contains(t, code, "Discount decimal.Decimal   `json:\"discount\"`")
+	assert.Contains(t, code, "Tags     *sets.Set[string] `json:\"tags,omitempty\"`")
+	assert.Contains(t, code, "Lines    *[]string         `json:\"lines,omitempty\"`")
+	assert.Contains(t, code, "Note     *Note             `json:\"note,omitempty\"`")
+	assert.Contains(t, code, "Note *Note `json:\"note,omitempty\"`")
+	assert.Contains(t, code, `"github.com/shopspring/decimal"`)
+	assert.Contains(t, code, `"example.com/sets"`)
+
+	opts.Generate = GenerateOptions{StdHTTPServer: true}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "var id OrderID")
+
+	opts.OutputOptions.TypeMapping.Overrides = []TypeMappingOverride{{Path: "#/components/schemas/[", SimpleTypeSpec: SimpleTypeSpec{Type: "Note"}}}
+	_, err = Generate(swagger, opts)
+	assert.ErrorContains(t, err, "invalid type-mapping override path")
+}
+
+// TestFormatTypes verifies that output-options.format-types maps the formats
+// which plain Go types can't represent to the types of pkg/types.
+func TestFormatTypes(t *testing.T) {
+	const spec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Formats
+paths:
+  /things/{id}:
+    get:
+      operationId: getThing
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: int64
+        - name: at
+          in: query
+          schema:
+            type: string
+            format: time
+      responses:
+        "200":
+          description: A thing
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Thing"
+components:
+  schemas:
+    Thing:
+      type: object
+      required: [price]
+      properties:
+        price:
+          type: string
+          format: decimal
+        count:
+          type: integer
+          format: int64
+        total:
+          type: number
+          format: decimal
+`
+	loader := openapi3.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true},
+	}
+
+	// By default, the formats keep their plain types.
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Price string   `json:\"price\"`")
+	assert.Contains(t, code, "Total *float32 `json:\"total,omitempty\"`")
+	assert.NotContains(t, code, "pkg/types")
+
+	opts.OutputOptions.FormatTypes = true
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Price types.Decimal        `json:\"price\"`")
+	assert.Contains(t, code, "Count *int64               `json:\"count,omitempty\"`")
+	assert.Contains(t, code, "At *types.TimeOfDay `form:\"at,omitempty\" json:\"at,omitempty\"`")
+	assert.Contains(t, code, "func (c *Client) GetThing(ctx context.Context, id types.StringInt64, params *GetThingParams")
+	assert.Contains(t, code, "Total *types.DecimalNumber `json:\"total,omitempty\"`")
+	assert.Contains(t, code, `"github.com/oapi-codegen/oapi-codegen/v2/pkg/types"`)
+
+	// The user's type mapping takes precedence.
+	opts.OutputOptions.TypeMapping = &TypeMapping{String: FormatMapping{Formats: map[string]SimpleTypeSpec{"decimal": {Type: "string"}}}}
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Price string               `json:\"price\"`")
+}
@@ -1,9 +1,9 @@
 package codegen
 
 import (
-	"errors"
 	"fmt"
 	"go/token"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -28,20 +28,85 @@ import (
 // is validated at its own definition, and the contents of external documents are
 // never copied into the generated output, so they cannot affect it.
 func ValidateSpec(spec *openapi3.T) error {
+	return diagnosticsError(validateSpec(spec))
+}
+
+// validateSpec returns the diagnostics of ValidateSpec: the values of spec
+// which can't be represented in the generated code, and the constructs which
+// are ignored, as warnings.
+func validateSpec(spec *openapi3.T) []Diagnostic {
 	if spec == nil {
 		return nil
 	}
 	v := &specValidator{}
 	v.walkDocument(spec)
-	return errors.Join(v.errs...)
+	return v.diagnostics
 }
 
 type specValidator struct {
-	errs []error
+	diagnostics []Diagnostic
+	// origin is the origin of the innermost object being walked which has
+	// one.
+	origin *openapi3.Origin
+	// pointer is the JSON pointer of the object being walked, as its
+	// unescaped reference tokens.
+	pointer []string
+	// pointers, when it isn't nil, records the JSON pointers of the schemas
+	// and the operations walked, where they're defined.
+	pointers *specPointers
+}
+
+// specPointers are the JSON pointers of the schemas and the operations of a
+// spec, where they're defined rather than referenced.
+type specPointers struct {
+	schemas    map[*openapi3.Schema]string
+	operations map[*openapi3.Operation]string
+}
+
+// newSpecPointers returns the JSON pointers of the schemas and the
+// operations of spec.
+func newSpecPointers(spec *openapi3.T) *specPointers {
+	pointers := &specPointers{
+		schemas:    map[*openapi3.Schema]string{},
+		operations: map[*openapi3.Operation]string{},
+	}
+	if spec != nil {
+		v := &specValidator{pointers: pointers}
+		v.walkDocument(spec)
+	}
+	return pointers
 }
 
 func (v *specValidator) addf(format string, args ...any) {
-	v.errs = append(v.errs, fmt.Errorf(format, args...))
+	v.report(SeverityError, CodeInvalidValue, format, args...)
+}
+
+func (v *specValidator) warnf(code string, format string, args ...any) {
+	v.report(SeverityWarning, code, format, args...)
+}
+
+func (v *specValidator) report(severity Severity, code string, format string, args ...any) {
+	d := newDiagnostic(severity, code, v.origin, format, args...)
+	d.Pointer = jsonPointer(v.pointer...)
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+// enter makes origin, if it's known, the origin of the problems found until
+// the returned function is called, on leaving the object it's the origin of.
+func (v *specValidator) enter(origin *openapi3.Origin) func() {
+	outer := v.origin
+	if origin != nil && origin.Key != nil {
+		v.origin = origin
+	}
+	return func() { v.origin = outer }
+}
+
+// at appends tokens to the JSON pointer of the object being walked until the
+// returned function is called.
+func (v *specValidator) at(tokens ...string) func() {
+	depth := len(v.pointer)
+	v.pointer = append(v.pointer, tokens...)
+	return func() { v.pointer = v.pointer[:depth] }
 }
 
 // checkText validates a free-form string that is copied verbatim into the
@@ -226,14 +291,18 @@ func (v *specValidator) walkDocument(spec *openapi3.T) {
 		paths := spec.Paths.Map()
 		for _, path := range SortedMapKeys(paths) {
 			where := fmt.Sprintf("path %q", path)
+			leave := v.at("paths", path)
 			v.checkText(path, "OpenAPI path")
 			v.walkPathItem(paths[path], where)
+			leave()
 		}
 	}
 	for _, name := range SortedMapKeys(spec.Webhooks) {
 		where := fmt.Sprintf("webhook %q", name)
+		leave := v.at("webhooks", name)
 		v.checkText(name, "webhook name")
 		v.walkPathItem(spec.Webhooks[name], where)
+		leave()
 	}
 	if spec.Components != nil {
 		v.walkComponents(spec.Components)
@@ -242,22 +311,34 @@ func (v *specValidator) walkDocument(spec *openapi3.T) {
 
 func (v *specValidator) walkComponents(c *openapi3.Components) {
 	for _, name := range SortedMapKeys(c.Schemas) {
+		leave := v.at("components", "schemas", name)
 		v.walkSchemaRef(c.Schemas[name], fmt.Sprintf("schema %q", name))
+		leave()
 	}
 	for _, name := range SortedMapKeys(c.Parameters) {
+		leave := v.at("components", "parameters", name)
 		v.walkParameterRef(c.Parameters[name], fmt.Sprintf("component parameter %q", name))
+		leave()
 	}
 	for _, name := range SortedMapKeys(c.RequestBodies) {
+		leave := v.at("components", "requestBodies", name)
 		v.walkRequestBodyRef(c.RequestBodies[name], fmt.Sprintf("component request body %q", name))
+		leave()
 	}
 	for _, name := range SortedMapKeys(c.Responses) {
+		leave := v.at("components", "responses", name)
 		v.walkResponseRef(c.Responses[name], fmt.Sprintf("component response %q", name))
+		leave()
 	}
 	for _, name := range SortedMapKeys(c.Headers) {
+		leave := v.at("components", "headers", name)
 		v.walkHeaderRef(c.Headers[name], fmt.Sprintf("component header %q", name))
+		leave()
 	}
 	for _, name := range SortedMapKeys(c.Callbacks) {
+		leave := v.at("components", "callbacks", name)
 		v.walkCallbackRef(c.Callbacks[name], fmt.Sprintf("component callback %q", name))
+		leave()
 	}
 }
 
@@ -265,11 +346,16 @@ func (v *specValidator) walkPathItem(item *openapi3.PathItem, where string) {
 	if item == nil {
 		return
 	}
-	for _, p := range item.Parameters {
+	defer v.enter(item.Origin)()
+	for i, p := range item.Parameters {
+		leave := v.at("parameters", strconv.Itoa(i))
 		v.walkParameterRef(p, where)
+		leave()
 	}
 	for method, op := range item.Operations() {
+		leave := v.at(strings.ToLower(method))
 		v.walkOperation(op, fmt.Sprintf("%s %s", method, where))
+		leave()
 	}
 }
 
@@ -277,23 +363,35 @@ func (v *specValidator) walkOperation(op *openapi3.Operation, where string) {
 	if op == nil {
 		return
 	}
+	defer v.enter(op.Origin)()
+	if v.pointers != nil {
+		v.pointers.operations[op] = jsonPointer(v.pointer...)
+	}
 	v.checkExtensions(op.Extensions, where)
 	if op.Security != nil {
 		v.checkSecurity(*op.Security, where)
 	}
-	for _, p := range op.Parameters {
+	for i, p := range op.Parameters {
+		leave := v.at("parameters", strconv.Itoa(i))
 		v.walkParameterRef(p, where)
+		leave()
 	}
+	leave := v.at("requestBody")
 	v.walkRequestBodyRef(op.RequestBody, where)
+	leave()
 	if op.Responses != nil {
 		responses := op.Responses.Map()
 		for _, name := range SortedMapKeys(responses) {
+			leave := v.at("responses", name)
 			v.walkResponseRef(responses[name], fmt.Sprintf("response %q in %s", name, where))
+			leave()
 		}
 	}
 	for _, name := range SortedMapKeys(op.Callbacks) {
+		leave := v.at("callbacks", name)
 		v.checkText(name, fmt.Sprintf("callback name in %s", where))
 		v.walkCallbackRef(op.Callbacks[name], fmt.Sprintf("callback %q in %s", name, where))
+		leave()
 	}
 }
 
@@ -306,11 +404,13 @@ func (v *specValidator) walkParameterRef(ref *openapi3.ParameterRef, where strin
 		return
 	}
 	p := ref.Value
+	defer v.enter(p.Origin)()
 	loc := fmt.Sprintf("parameter %q in %s", p.Name, where)
 	v.checkText(p.Name, "parameter name in "+where)
 	v.checkText(p.Style, "style of "+loc)
 	v.checkExtensions(p.Extensions, loc)
 	v.walkContent(p.Content, loc)
+	defer v.at("schema")()
 	v.walkSchemaRef(p.Schema, loc)
 }
 
@@ -322,6 +422,7 @@ func (v *specValidator) walkRequestBodyRef(ref *openapi3.RequestBodyRef, where s
 	if ref.Ref != "" || ref.Value == nil {
 		return
 	}
+	defer v.enter(ref.Value.Origin)()
 	loc := "request body in " + where
 	v.checkExtensions(ref.Value.Extensions, loc)
 	v.walkContent(ref.Value.Content, loc)
@@ -336,9 +437,12 @@ func (v *specValidator) walkResponseRef(ref *openapi3.ResponseRef, where string)
 		return
 	}
 	r := ref.Value
+	defer v.enter(r.Origin)()
 	for _, name := range SortedMapKeys(r.Headers) {
+		leave := v.at("headers", name)
 		v.checkText(name, "response header name in "+where)
 		v.walkHeaderRef(r.Headers[name], fmt.Sprintf("header %q in %s", name, where))
+		leave()
 	}
 	v.walkContent(r.Content, where)
 	v.checkExtensions(r.Extensions, where)
@@ -352,8 +456,10 @@ func (v *specValidator) walkHeaderRef(ref *openapi3.HeaderRef, where string) {
 	if ref.Ref != "" || ref.Value == nil {
 		return
 	}
+	defer v.enter(ref.Value.Origin)()
 	v.checkExtensions(ref.Value.Extensions, where)
 	v.walkContent(ref.Value.Content, where)
+	defer v.at("schema")()
 	v.walkSchemaRef(ref.Value.Schema, where)
 }
 
@@ -367,20 +473,26 @@ func (v *specValidator) walkCallbackRef(ref *openapi3.CallbackRef, where string)
 	}
 	items := ref.Value.Map()
 	for _, expr := range SortedMapKeys(items) {
+		leave := v.at(expr)
 		v.walkPathItem(items[expr], where)
+		leave()
 	}
 }
 
 func (v *specValidator) walkContent(content openapi3.Content, where string) {
 	for _, mediaType := range SortedMapKeys(content) {
+		leaveContent := v.at("content", mediaType)
 		v.checkNoControl(mediaType, "content type in "+where)
-		media := content[mediaType]
-		if media == nil {
-			continue
+		if media := content[mediaType]; media != nil {
+			loc := fmt.Sprintf("content type %q in %s", mediaType, where)
+			leave := v.enter(media.Origin)
+			v.checkExtensions(media.Extensions, loc)
+			leaveSchema := v.at("schema")
+			v.walkSchemaRef(media.Schema, loc)
+			leaveSchema()
+			leave()
 		}
-		loc := fmt.Sprintf("content type %q in %s", mediaType, where)
-		v.checkExtensions(media.Extensions, loc)
-		v.walkSchemaRef(media.Schema, loc)
+		leaveContent()
 	}
 }
 
@@ -404,6 +516,12 @@ func (v *specValidator) walkSchema(s *openapi3.Schema, where string) {
 	if s == nil {
 		return
 	}
+	defer v.enter(s.Origin)()
+	if v.pointers != nil {
+		if _, ok := v.pointers.schemas[s]; !ok {
+			v.pointers.schemas[s] = jsonPointer(v.pointer...)
+		}
+	}
 	v.checkExtensions(s.Extensions, where)
 	v.checkText(s.Format, "format in "+where)
 	if s.Type != nil {
@@ -424,22 +542,37 @@ func (v *specValidator) walkSchema(s *openapi3.Schema, where string) {
 			v.checkText(key, "discriminator mapping key in "+where)
 		}
 	}
+	// patternProperties are only in OpenAPI 3.1, and no field, or map
+	// value type, is generated for them.
+	if len(s.PatternProperties) > 0 {
+		v.warnf(CodeIgnoredPatternProperties, "patternProperties in %s are ignored, so the properties they match aren't generated", where)
+	}
+
 	for _, name := range SortedMapKeys(s.Properties) {
+		leave := v.at("properties", name)
 		v.checkText(name, "property name in "+where)
 		v.walkSchemaRef(s.Properties[name], fmt.Sprintf("property %q in %s", name, where))
+		leave()
 	}
-	v.walkSchemaRef(s.Items, "items in "+where)
+	v.walkSubschemaRef(s.Items, "items in "+where, "items")
 	if s.AdditionalProperties.Schema != nil {
-		v.walkSchemaRef(s.AdditionalProperties.Schema, "additionalProperties in "+where)
+		v.walkSubschemaRef(s.AdditionalProperties.Schema, "additionalProperties in "+where, "additionalProperties")
 	}
 	for i, sub := range s.AllOf {
-		v.walkSchemaRef(sub, fmt.Sprintf("allOf[%d] in %s", i, where))
+		v.walkSubschemaRef(sub, fmt.Sprintf("allOf[%d] in %s", i, where), "allOf", strconv.Itoa(i))
 	}
 	for i, sub := range s.AnyOf {
-		v.walkSchemaRef(sub, fmt.Sprintf("anyOf[%d] in %s", i, where))
+		v.walkSubschemaRef(sub, fmt.Sprintf("anyOf[%d] in %s", i, where), "anyOf", strconv.Itoa(i))
 	}
 	for i, sub := range s.OneOf {
-		v.walkSchemaRef(sub, fmt.Sprintf("oneOf[%d] in %s", i, where))
+		v.walkSubschemaRef(sub, fmt.Sprintf("oneOf[%d] in %s", i, where), "oneOf", strconv.Itoa(i))
 	}
-	v.walkSchemaRef(s.Not, "not in "+where)
+	v.walkSubschemaRef(s.Not, "not in "+where, "not")
+}
+
+// walkSubschemaRef walks the subschema of a schema at the reference tokens
+// of its JSON pointer within the schema.
+func (v *specValidator) walkSubschemaRef(ref *openapi3.SchemaRef, where string, tokens ...string) {
+	defer v.at(tokens...)()
+	v.walkSchemaRef(ref, where)
 }
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Decimal is an arbitrary-precision decimal number, for `format: decimal`.
+// It's encoded as a JSON string so that no precision is lost, and keeps the
+// exact representation it was parsed from.
+type Decimal struct {
+	value string
+}
+
+// decimalPattern matches the decimal numbers accepted by ParseDecimal.
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// ParseDecimal parses a decimal number, such as "-12.50" or "1e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{value: s}, nil
+}
+
+// String returns the decimal as it was parsed, or "0" for the zero value.
+func (d Decimal) String() string {
+	if d.value == "" {
+		return "0"
+	}
+	return d.value
+}
+
+// Rat returns the exact value of the decimal.
+func (d Decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}
+
+// Equal returns true if d and other have the same value, however they're
+// written, so that "1.0" is equal to "1.00" and "1e2" to "100".
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Rat().Cmp(other.Rat()) == 0
+}
+
+// Float64 returns the nearest float64 value to the decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes the decimal as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a decimal from a JSON string, or from a JSON number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		s = n.String()
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Bind binds a decimal from a path, query or header parameter.
+func (d *Decimal) Bind(src string) error {
+	return d.UnmarshalText([]byte(src))
+}
+
+// DecimalNumber is a Decimal for `type: number, format: decimal`. It's
+// encoded as a JSON number, with the digits it was parsed from, so that no
+// precision is lost.
+type DecimalNumber struct {
+	Decimal
+}
+
+// ParseDecimalNumber parses a decimal number, such as "-12.50" or "1e-3".
+func ParseDecimalNumber(s string) (DecimalNumber, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return DecimalNumber{}, err
+	}
+	return DecimalNumber{Decimal: d}, nil
+}
+
+// Equal returns true if d and other have the same value, however they're
+// written.
+func (d DecimalNumber) Equal(other DecimalNumber) bool {
+	return d.Decimal.Equal(other.Decimal)
+}
+
+// MarshalJSON encodes the decimal as a JSON number. The forms which
+// ParseDecimal accepts but JSON doesn't, such as "+1", "01", ".5" and "1.",
+// are written as "1", "1", "0.5" and "1".
+func (d DecimalNumber) MarshalJSON() ([]byte, error) {
+	s := strings.TrimPrefix(d.String(), "+")
+	sign := ""
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = "-", rest
+	}
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(s), "e")
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+	n := sign + whole
+	if fraction != "" {
+		n += "." + fraction
+	}
+	if hasExponent {
+		n += "e" + exponent
+	}
+	return []byte(n), nil
+}
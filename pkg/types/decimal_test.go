@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	d, err := ParseDecimal("1.50")
+	require.NoError(t, err)
+	assert.Equal(t, "1.50", d.String())
+	assert.Equal(t, 1.5, d.Float64())
+	assert.Equal(t, "0", Decimal{}.String())
+
+	// Decimals are equal by value, however they're written.
+	other, err := ParseDecimal("15e-1")
+	require.NoError(t, err)
+	assert.True(t, d.Equal(other))
+
+	// Decimals are written as JSON strings, and read from strings or
+	// numbers.
+	out, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, `"1.50"`, string(out))
+	require.NoError(t, json.Unmarshal([]byte(`12.5e3`), &d))
+	assert.Equal(t, "12.5e3", d.String())
+
+	_, err = ParseDecimal("1,5")
+	assert.Error(t, err)
+}
+
+func TestDecimalNumberMarshalJSON(t *testing.T) {
+	for in, out := range map[string]string{
+		"0":       "0",
+		"+1":      "1",
+		"01":      "1",
+		"1.":      "1",
+		".5":      "0.5",
+		"-.5E-2":  "-0.5e-2",
+		"100.010": "100.010",
+	} {
+		d, err := ParseDecimalNumber(in)
+		require.NoError(t, err)
+		got, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.Equal(t, out, string(got), in)
+		assert.True(t, json.Valid(got), in)
+	}
+
+	_, err := ParseDecimalNumber("1,5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+// Package types provides the Go types which output-options.format-types maps
+// OpenAPI formats to, so that generated code can represent them without
+// losing precision:
+//
+//   - TimeOfDay, for `type: string, format: time`
+//   - Decimal, for `type: string, format: decimal`
+//   - DecimalNumber, for `type: number, format: decimal`
+//   - StringInt64 and StringUint64, for `type: string` with `format: int64`
+//     and `format: uint64`
+//
+// Each type marshals to and from JSON and text, and implements the Bind
+// method used to bind path, query and header parameters.
+package types
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// StringInt64 is a signed 64-bit integer encoded as a string, as is common
+// for `type: string, format: int64`, since JSON numbers can't represent
+// every int64 exactly.
+type StringInt64 int64
+
+// MarshalText implements encoding.TextMarshaler.
+func (i StringInt64) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(i), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *StringInt64) UnmarshalText(data []byte) error {
+	v, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = StringInt64(v)
+	return nil
+}
+
+// MarshalJSON encodes the integer as a JSON string.
+func (i StringInt64) MarshalJSON() ([]byte, error) {
+	text, _ := i.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the integer from a JSON string, or from a JSON number.
+func (i *StringInt64) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		s = n.String()
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// Bind binds the integer from a path, query or header parameter.
+func (i *StringInt64) Bind(src string) error {
+	return i.UnmarshalText([]byte(src))
+}
+
+// StringUint64 is an unsigned 64-bit integer encoded as a string, as is
+// common for `type: string, format: uint64`, since JSON numbers can't
+// represent every uint64 exactly.
+type StringUint64 uint64
+
+// MarshalText implements encoding.TextMarshaler.
+func (i StringUint64) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(i), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *StringUint64) UnmarshalText(data []byte) error {
+	v, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = StringUint64(v)
+	return nil
+}
+
+// MarshalJSON encodes the integer as a JSON string.
+func (i StringUint64) MarshalJSON() ([]byte, error) {
+	text, _ := i.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the integer from a JSON string, or from a JSON number.
+func (i *StringUint64) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		s = n.String()
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// Bind binds the integer from a path, query or header parameter.
+func (i *StringUint64) Bind(src string) error {
+	return i.UnmarshalText([]byte(src))
+}
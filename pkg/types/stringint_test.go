@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringInt64(t *testing.T) {
+	i := StringInt64(-9007199254740993)
+	out, err := json.Marshal(i)
+	require.NoError(t, err)
+	assert.Equal(t, `"-9007199254740993"`, string(out))
+
+	// Integers are read from strings or numbers.
+	require.NoError(t, json.Unmarshal([]byte(`"42"`), &i))
+	assert.Equal(t, StringInt64(42), i)
+	require.NoError(t, json.Unmarshal([]byte(`43`), &i))
+	assert.Equal(t, StringInt64(43), i)
+	assert.Error(t, i.Bind("4.2"))
+}
+
+func TestStringUint64(t *testing.T) {
+	u := StringUint64(18446744073709551615)
+	out, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Equal(t, `"18446744073709551615"`, string(out))
+
+	require.NoError(t, u.Bind("7"))
+	assert.Equal(t, StringUint64(7), u)
+	assert.Error(t, u.Bind("-1"))
+}
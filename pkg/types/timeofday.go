@@ -0,0 +1,97 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time with no date, for `format: time`. It's
+// encoded as "15:04:05", with fractional seconds when they're set, and with
+// its offset from UTC when it has one, as in "15:04:05Z" or
+// "15:04:05+02:00".
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	// Offset is the offset from UTC in seconds east of UTC, when HasOffset
+	// is set.
+	Offset    int
+	HasOffset bool
+}
+
+// ParseTimeOfDay parses a wall-clock time, such as "09:30:00" or
+// "23:59:59.5", optionally followed by its offset from UTC, such as
+// "09:30:00Z" or "09:30:00+02:00".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if t, err := time.Parse("15:04:05Z07:00", s); err == nil {
+		tod := TimeOfDayOf(t)
+		_, tod.Offset = t.Zone()
+		tod.HasOffset = true
+		return tod, nil
+	}
+	t, err := time.Parse("15:04:05", s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// TimeOfDayOf returns the wall-clock time of t, in t's location, with no
+// offset.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// On returns the time on the given date, at the time's offset when it has
+// one, and otherwise in loc.
+func (t TimeOfDay) On(year int, month time.Month, day int, loc *time.Location) time.Time {
+	if t.HasOffset {
+		loc = time.FixedZone("", t.Offset)
+	}
+	return time.Date(year, month, day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// String returns the time in "15:04:05" form, followed by its offset when it
+// has one.
+func (t TimeOfDay) String() string {
+	if t.HasOffset {
+		return t.On(0, time.January, 1, nil).Format("15:04:05.999999999Z07:00")
+	}
+	return t.On(0, time.January, 1, time.UTC).Format("15:04:05.999999999")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the time as a JSON string.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes the time from a JSON string.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// Bind binds the time from a path, query or header parameter.
+func (t *TimeOfDay) Bind(src string) error {
+	return t.UnmarshalText([]byte(src))
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	for _, tc := range []struct {
+		in        string
+		offset    int
+		hasOffset bool
+		out       string
+	}{
+		{in: "09:30:00", out: "09:30:00"},
+		{in: "09:59:59.5", out: "09:59:59.5"},
+		{in: "09:30:00Z", hasOffset: true, out: "09:30:00Z"},
+		{in: "09:30:00+00:00", hasOffset: true, out: "09:30:00Z"},
+		{in: "09:30:00.25+02:00", offset: 2 * 60 * 60, hasOffset: true, out: "09:30:00.25+02:00"},
+		{in: "09:30:00-05:30", offset: -(5*60 + 30) * 60, hasOffset: true, out: "09:30:00-05:30"},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			tod, err := ParseTimeOfDay(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, 9, tod.Hour)
+			assert.Equal(t, tc.offset, tod.Offset)
+			assert.Equal(t, tc.hasOffset, tod.HasOffset)
+			assert.Equal(t, tc.out, tod.String())
+		})
+	}
+
+	_, err := ParseTimeOfDay("09:30")
+	assert.Error(t, err)
+	_, err = ParseTimeOfDay("09:30:00+2")
+	assert.Error(t, err)
+
+	// A time with an offset is on the date at that offset, whatever loc is.
+	tod, err := ParseTimeOfDay("09:30:00+02:00")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2024, time.March, 1, 7, 30, 0, 0, time.UTC).Equal(tod.On(2024, time.March, 1, time.Local)))
+}
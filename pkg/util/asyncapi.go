@@ -0,0 +1,261 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtAsyncAPI holds, in the OpenAPI documents which wrap AsyncAPI documents,
+// the AsyncAPI description of the channels and messages of the document.
+const ExtAsyncAPI = "x-oapi-codegen-asyncapi"
+
+// asyncAPIPayloadSuffix suffixes the names of the component schemas of the
+// payloads of messages.
+const asyncAPIPayloadSuffix = "Payload"
+
+// AsyncAPI describes the channels and messages of an AsyncAPI document,
+// from which only models are generated: for its component schemas and the
+// payloads of its component messages.
+type AsyncAPI struct {
+	// Version is the version of AsyncAPI the document declares.
+	Version string `json:"version" yaml:"version"`
+	// Channels are the channels of the document, in the order they're
+	// declared.
+	Channels []AsyncAPIChannel `json:"channels,omitempty" yaml:"channels,omitempty"`
+	// Messages are the component messages of the document, in the order
+	// they're declared.
+	Messages []AsyncAPIMessage `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
+
+// AsyncAPIChannel is a channel of an AsyncAPI document.
+type AsyncAPIChannel struct {
+	// Name is the key of the channel in `channels`, which is its address
+	// in AsyncAPI 2.x.
+	Name string `json:"name" yaml:"name"`
+	// Address is the address of the channel, which defaults to its name.
+	Address string `json:"address" yaml:"address"`
+}
+
+// AsyncAPIMessage is a component message of an AsyncAPI document.
+type AsyncAPIMessage struct {
+	// Name is the key of the message in `components.messages`.
+	Name string `json:"name" yaml:"name"`
+	// MessageName is the `name` of the message, which defaults to Name.
+	MessageName string `json:"messageName" yaml:"messageName"`
+	// Payload is the name of the component schema of the payload of the
+	// message, or "" if it has none.
+	Payload string `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// IsModelsOnlySpec reports whether swagger was loaded from JSON Schema or
+// AsyncAPI documents, from which only models are generated.
+func IsModelsOnlySpec(swagger *openapi3.T) bool {
+	if swagger == nil {
+		return false
+	}
+	_, ok := swagger.Extensions[ExtAsyncAPI]
+	return ok || IsJSONSchemaSpec(swagger)
+}
+
+// AsyncAPISpec returns the AsyncAPI description of the AsyncAPI document
+// which swagger wraps, or nil if it wasn't loaded from one.
+func AsyncAPISpec(swagger *openapi3.T) (*AsyncAPI, error) {
+	if swagger == nil {
+		return nil, nil
+	}
+	ext, ok := swagger.Extensions[ExtAsyncAPI]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	var asyncAPI AsyncAPI
+	if err := json.Unmarshal(data, &asyncAPI); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ExtAsyncAPI, err)
+	}
+	return &asyncAPI, nil
+}
+
+// wrapAsyncAPI returns the OpenAPI 3.1 document wrapping the AsyncAPI
+// document node, at location, whose component schemas are its component
+// schemas and the payloads of its component messages, named after the
+// messages, such as `UserSignedUpPayload` for `UserSignedUp`.
+func wrapAsyncAPI(node *yaml.Node, location string) (*yaml.Node, error) {
+	root := documentRoot(node)
+	version := scalarValue(root, "asyncapi")
+	if !strings.HasPrefix(version, "2.") && !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("the AsyncAPI version %q of %#v isn't supported, only 2.x and 3.x are", version, location)
+	}
+	asyncAPI := AsyncAPI{Version: version}
+
+	channels := mappingValue(root, "channels")
+	for i := 0; channels != nil && i+1 < len(channels.Content); i += 2 {
+		channel := AsyncAPIChannel{Name: channels.Content[i].Value, Address: channels.Content[i].Value}
+		if address := mappingValue(channels.Content[i+1], "address"); address != nil && !strings.HasPrefix(version, "2.") {
+			// The address of a channel is null when it's unknown, or
+			// dynamic, so there's no constant for it.
+			if address.Tag == "!!null" {
+				continue
+			}
+			channel.Address = address.Value
+		}
+		asyncAPI.Channels = append(asyncAPI.Channels, channel)
+	}
+
+	components := mappingValue(root, "components")
+	schemas := &yaml.Node{Kind: yaml.MappingNode}
+	defined := map[string]bool{}
+	if componentSchemas := mappingValue(components, "schemas"); componentSchemas != nil {
+		for i := 0; i+1 < len(componentSchemas.Content); i += 2 {
+			defined[componentSchemas.Content[i].Value] = true
+		}
+		schemas.Content = append(schemas.Content, componentSchemas.Content...)
+	}
+
+	messages := mappingValue(components, "messages")
+	for i := 0; messages != nil && i+1 < len(messages.Content); i += 2 {
+		name := messages.Content[i].Value
+		message, target, err := asyncAPIMessage(messages, name, location)
+		if err != nil {
+			return nil, err
+		}
+		entry := AsyncAPIMessage{Name: name, MessageName: name}
+		if messageName := scalarValue(message, "name"); messageName != "" {
+			entry.MessageName = messageName
+		}
+
+		payload, err := asyncAPIPayload(message, name, location)
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil && target != name {
+			payload = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+				scalarNode("$ref"), scalarNode("#/components/schemas/" + target + asyncAPIPayloadSuffix),
+			}}
+		}
+		if payload != nil {
+			entry.Payload = name + asyncAPIPayloadSuffix
+			if defined[entry.Payload] {
+				return nil, fmt.Errorf("the schema %q of %#v is also the name of the payload of the message %q", entry.Payload, location, name)
+			}
+			defined[entry.Payload] = true
+			schemas.Content = append(schemas.Content, scalarNode(entry.Payload), payload)
+		}
+		asyncAPI.Messages = append(asyncAPI.Messages, entry)
+	}
+
+	// The payloads of messages may be referenced where they're declared.
+	rewriteMessageRefs(schemas)
+	schemaNodes := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		schemaNodes[schemas.Content[i].Value] = schemas.Content[i+1]
+	}
+	for i := 1; i < len(schemas.Content); i += 2 {
+		if err := inlineSubschemaRefs(schemas.Content[i], schemaNodes, map[string]bool{}); err != nil {
+			return nil, err
+		}
+	}
+
+	var ext yaml.Node
+	if err := ext.Encode(asyncAPI); err != nil {
+		return nil, err
+	}
+	info := mappingValue(root, "info")
+	title, infoVersion := scalarValue(info, "title"), scalarValue(info, "version")
+	if title == "" {
+		title = "AsyncAPI"
+	}
+	if infoVersion == "" {
+		infoVersion = "0.0.0"
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		scalarNode("openapi"), scalarNode("3.1.0"),
+		scalarNode("info"), {Kind: yaml.MappingNode, Content: []*yaml.Node{
+			scalarNode("title"), scalarNode(title),
+			scalarNode("version"), scalarNode(infoVersion),
+		}},
+		scalarNode("components"), {Kind: yaml.MappingNode, Content: []*yaml.Node{
+			scalarNode("schemas"), schemas,
+		}},
+		scalarNode(ExtAsyncAPI), &ext,
+	}}, nil
+}
+
+// asyncAPIMessage returns the component message name of messages, following
+// its references to other component messages, and the name of the message
+// it references in the end, which is name if it isn't a reference.
+func asyncAPIMessage(messages *yaml.Node, name, location string) (*yaml.Node, string, error) {
+	message, target := mappingValue(messages, name), name
+	for seen := map[string]bool{name: true}; ; {
+		ref := scalarValue(message, "$ref")
+		if ref == "" {
+			return message, target, nil
+		}
+		var ok bool
+		target, ok = strings.CutPrefix(ref, "#/components/messages/")
+		if !ok || strings.Contains(target, "/") {
+			return nil, "", fmt.Errorf("the message %q of %#v references %q, but only the component messages of the document may be referenced", name, location, ref)
+		}
+		if seen[target] {
+			return nil, "", fmt.Errorf("the message %q of %#v references itself", name, location)
+		}
+		seen[target] = true
+		if message = mappingValue(messages, target); message == nil {
+			return nil, "", fmt.Errorf("the message %q of %#v references %q, which doesn't exist", name, location, ref)
+		}
+	}
+}
+
+// asyncAPIPayload returns the schema of the payload of the message name, or
+// nil if it has none. Only payloads in the AsyncAPI and JSON Schema formats
+// are supported.
+func asyncAPIPayload(message *yaml.Node, name, location string) (*yaml.Node, error) {
+	payload := mappingValue(message, "payload")
+	if payload == nil {
+		return nil, nil
+	}
+	schemaFormat := scalarValue(message, "schemaFormat")
+	// AsyncAPI 3.x declares the format of a payload with its schema, in a
+	// Multi Format Schema Object.
+	if schema := mappingValue(payload, "schema"); schema != nil && mappingValue(payload, "schemaFormat") != nil {
+		schemaFormat, payload = scalarValue(payload, "schemaFormat"), schema
+	}
+	if schemaFormat != "" && !strings.HasPrefix(schemaFormat, "application/vnd.aai.asyncapi") && !strings.HasPrefix(schemaFormat, "application/schema+") {
+		return nil, fmt.Errorf("the payload of the message %q of %#v has the schemaFormat %q, but only AsyncAPI and JSON Schema payloads are supported", name, location, schemaFormat)
+	}
+	return payload, nil
+}
+
+// rewriteMessageRefs rewrites the references of node to the payloads of
+// component messages, such as `#/components/messages/UserSignedUp/payload`,
+// to the component schemas of the payloads.
+func rewriteMessageRefs(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		if ref := mappingValue(node, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+			if message, ok := strings.CutPrefix(ref.Value, "#/components/messages/"); ok {
+				if name, ok := strings.CutSuffix(message, "/payload"); ok && !strings.Contains(name, "/") {
+					ref.Value = "#/components/schemas/" + name + asyncAPIPayloadSuffix
+				}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		rewriteMessageRefs(child)
+	}
+}
+
+// loadAsyncAPI loads the OpenAPI document wrapping the AsyncAPI document
+// node, at filePath, with loader.
+func loadAsyncAPI(loader *openapi3.Loader, node *yaml.Node, filePath string) (*openapi3.T, error) {
+	wrapper, err := wrapAsyncAPI(node, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return loadWrapper(loader, wrapper, specLocation(filePath))
+}
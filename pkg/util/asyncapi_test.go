@@ -0,0 +1,90 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const asyncAPI3Spec = `asyncapi: 3.0.0
+info: {title: Orders, version: 1.0.0}
+channels:
+  orderCreated:
+    address: orders.created
+    messages:
+      OrderCreated: {$ref: "#/components/messages/OrderCreated"}
+  replies:
+    address: null
+components:
+  messages:
+    OrderCreated:
+      name: order.created
+      payload:
+        schemaFormat: application/vnd.aai.asyncapi+yaml;version=3.0.0
+        schema:
+          type: object
+          properties:
+            id: {type: string}
+            total: {$ref: "#/components/schemas/Money/properties/amount"}
+    OrderUpdated: {$ref: "#/components/messages/OrderCreated"}
+    Heartbeat:
+      summary: A message without payload.
+  schemas:
+    Money:
+      type: object
+      properties:
+        amount: {type: number}
+`
+
+func writeAsyncAPISpec(t *testing.T, spec string) string {
+	t.Helper()
+	specPath := filepath.Join(t.TempDir(), "asyncapi.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(spec), 0o644))
+	return specPath
+}
+
+func TestLoadAsyncAPI(t *testing.T) {
+	swagger, err := LoadSwagger(writeAsyncAPISpec(t, asyncAPI3Spec))
+	require.NoError(t, err)
+	assert.True(t, IsModelsOnlySpec(swagger))
+	assert.Equal(t, "Orders", swagger.Info.Title)
+
+	// The payloads of component messages are component schemas.
+	require.Len(t, swagger.Components.Schemas, 3)
+	payload := swagger.Components.Schemas["OrderCreatedPayload"].Value
+	require.NotNil(t, payload)
+	assert.Contains(t, payload.Properties, "id")
+	assert.True(t, payload.Properties["total"].Value.Type.Is("number"))
+	assert.Equal(t, "#/components/schemas/OrderCreatedPayload", swagger.Components.Schemas["OrderUpdatedPayload"].Ref)
+
+	asyncAPI, err := AsyncAPISpec(swagger)
+	require.NoError(t, err)
+	assert.Equal(t, &AsyncAPI{
+		Version: "3.0.0",
+		Channels: []AsyncAPIChannel{
+			{Name: "orderCreated", Address: "orders.created"},
+		},
+		Messages: []AsyncAPIMessage{
+			{Name: "OrderCreated", MessageName: "order.created", Payload: "OrderCreatedPayload"},
+			{Name: "OrderUpdated", MessageName: "order.created", Payload: "OrderUpdatedPayload"},
+			{Name: "Heartbeat", MessageName: "Heartbeat"},
+		},
+	}, asyncAPI)
+}
+
+func TestLoadAsyncAPIUnsupportedSchemaFormat(t *testing.T) {
+	_, err := LoadSwagger(writeAsyncAPISpec(t, `asyncapi: 2.6.0
+info: {title: Events, version: 1.0.0}
+channels: {}
+components:
+  messages:
+    Event:
+      schemaFormat: application/vnd.apache.avro;version=1.9.0
+      payload: {type: record, name: Event, fields: []}
+`))
+	assert.ErrorContains(t, err, `the payload of the message "Event"`)
+	assert.ErrorContains(t, err, `has the schemaFormat "application/vnd.apache.avro;version=1.9.0"`)
+}
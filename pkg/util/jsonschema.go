@@ -0,0 +1,393 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtJSONSchema marks the OpenAPI documents which wrap JSON Schema
+// documents, from which only models are generated, for every schema.
+const ExtJSONSchema = "x-oapi-codegen-json-schema"
+
+// IsJSONSchemaSpec reports whether swagger was loaded from JSON Schema
+// documents.
+func IsJSONSchemaSpec(swagger *openapi3.T) bool {
+	if swagger == nil {
+		return false
+	}
+	v, ok := swagger.Extensions[ExtJSONSchema].(bool)
+	return ok && v
+}
+
+// jsonSchemaRootName returns the name of the component schema of the root
+// schema of the JSON Schema document at location: its file name, without
+// extensions, such as `order` for `order.schema.json`.
+func jsonSchemaRootName(location string) string {
+	name := path.Base(filepath.ToSlash(location))
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// jsonSchemaDocument is a JSON Schema document being wrapped in an OpenAPI
+// document.
+type jsonSchemaDocument struct {
+	// location is the path, or the URL, of the document.
+	location string
+	// root is the root schema of the document.
+	root *yaml.Node
+	// rootName is the name of the component schema of the root schema.
+	rootName string
+}
+
+// newJSONSchemaDocument returns the JSON Schema document at location, whose
+// content is node.
+func newJSONSchemaDocument(location string, node *yaml.Node) (*jsonSchemaDocument, error) {
+	root := documentRoot(node)
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("the JSON Schema in %#v isn't an object", location)
+	}
+	return &jsonSchemaDocument{location: location, root: root, rootName: jsonSchemaRootName(location)}, nil
+}
+
+// schemas returns the component schemas of the document, by name: its
+// `$defs`, or `definitions`, and its root schema, unless it only holds
+// definitions.
+func (d *jsonSchemaDocument) schemas() (map[string]*yaml.Node, error) {
+	schemas := map[string]*yaml.Node{}
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	definesSchema := false
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		key, value := d.root.Content[i], d.root.Content[i+1]
+		switch key.Value {
+		case "$defs", "definitions":
+			if value.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("%s of the JSON Schema in %#v isn't an object", key.Value, d.location)
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				schemas[value.Content[j].Value] = value.Content[j+1]
+			}
+			continue
+		case "$schema", "$id":
+			continue
+		case "title", "description", "$comment":
+		default:
+			definesSchema = true
+		}
+		root.Content = append(root.Content, key, value)
+	}
+	if definesSchema {
+		if _, ok := schemas[d.rootName]; ok {
+			return nil, fmt.Errorf("the JSON Schema in %#v defines %q, which is the name of its root schema", d.location, d.rootName)
+		}
+		schemas[d.rootName] = root
+	}
+	return schemas, nil
+}
+
+// rewriteRefs rewrites the `$ref`s of node, in the document d, to the
+// component schemas which wrap what they reference. References to the
+// documents of local, by their cleaned path, are rewritten to the component
+// schemas of the document being generated.
+func (d *jsonSchemaDocument) rewriteRefs(node *yaml.Node, local map[string]bool) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				node.Content[i+1].Value = d.rewriteRef(node.Content[i+1].Value, local)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		d.rewriteRefs(child, local)
+	}
+}
+
+// rewriteRef returns ref, in the document d, rewritten to the component
+// schema which wraps what it references.
+func (d *jsonSchemaDocument) rewriteRef(ref string, local map[string]bool) string {
+	document, pointer, _ := strings.Cut(ref, "#")
+	rootName := d.rootName
+	if document != "" {
+		rootName = jsonSchemaRootName(document)
+		if local[d.resolve(document)] {
+			document = ""
+		}
+	}
+
+	for _, defs := range []string{"/$defs/", "/definitions/"} {
+		if strings.HasPrefix(pointer, defs) {
+			return document + "#/components/schemas/" + strings.TrimPrefix(pointer, defs)
+		}
+	}
+	return document + "#/components/schemas/" + rootName + pointer
+}
+
+// resolve returns the cleaned path of the document at the path relative to
+// d, or the path itself if it isn't a local file.
+func (d *jsonSchemaDocument) resolve(document string) string {
+	if strings.Contains(document, "://") || strings.Contains(d.location, "://") {
+		return document
+	}
+	if filepath.IsAbs(document) {
+		return filepath.Clean(document)
+	}
+	return filepath.Join(filepath.Dir(d.location), filepath.FromSlash(document))
+}
+
+// wrapJSONSchemas returns the OpenAPI 3.1 document wrapping the JSON Schema
+// documents docs, whose schemas are its component schemas. It's marked with
+// ExtJSONSchema if root.
+func wrapJSONSchemas(docs []*jsonSchemaDocument, root bool) (*yaml.Node, error) {
+	local := map[string]bool{}
+	for _, doc := range docs {
+		local[doc.resolve(filepath.Base(doc.location))] = true
+	}
+
+	definedBy := map[string]string{}
+	var names []string
+	schemas := map[string]*yaml.Node{}
+	for _, doc := range docs {
+		doc.rewriteRefs(doc.root, local)
+		docSchemas, err := doc.schemas()
+		if err != nil {
+			return nil, err
+		}
+		for name, schema := range docSchemas {
+			if other, ok := definedBy[name]; ok {
+				return nil, fmt.Errorf("the schema %q is defined by both %#v and %#v", name, other, doc.location)
+			}
+			definedBy[name] = doc.location
+			names = append(names, name)
+			schemas[name] = schema
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := inlineSubschemaRefs(schemas[name], schemas, map[string]bool{}); err != nil {
+			return nil, err
+		}
+	}
+
+	components := &yaml.Node{Kind: yaml.MappingNode}
+	for _, name := range names {
+		components.Content = append(components.Content, scalarNode(name), schemas[name])
+	}
+	title := "JSON Schema"
+	if len(docs) == 1 {
+		title = docs[0].rootName
+	}
+	wrapper := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		scalarNode("openapi"), scalarNode("3.1.0"),
+		scalarNode("info"), {Kind: yaml.MappingNode, Content: []*yaml.Node{
+			scalarNode("title"), scalarNode(title),
+			scalarNode("version"), scalarNode("0.0.0"),
+		}},
+		scalarNode("components"), {Kind: yaml.MappingNode, Content: []*yaml.Node{
+			scalarNode("schemas"), components,
+		}},
+	}}
+	if root {
+		wrapper.Content = append(wrapper.Content, scalarNode(ExtJSONSchema), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+	return wrapper, nil
+}
+
+// inlineSubschemaRefs replaces the nodes of node which reference the
+// subschemas of the component schemas schemas, such as
+// `#/components/schemas/order/properties/id`, which the generator can't name,
+// with copies of the subschemas. The references being inlined are inlining.
+func inlineSubschemaRefs(node *yaml.Node, schemas map[string]*yaml.Node, inlining map[string]bool) error {
+	if node.Kind == yaml.MappingNode {
+		ref := mappingValue(node, "$ref")
+		if ref != nil && ref.Kind == yaml.ScalarNode {
+			if subschema, ok := strings.CutPrefix(ref.Value, "#/components/schemas/"); ok && strings.Contains(subschema, "/") {
+				if inlining[ref.Value] {
+					return fmt.Errorf("the reference %q references itself", ref.Value)
+				}
+				tokens := strings.Split(subschema, "/")
+				target := schemas[unescapePointerToken(tokens[0])]
+				for _, token := range tokens[1:] {
+					if target == nil {
+						break
+					}
+					target = pointerChild(target, unescapePointerToken(token))
+				}
+				if target == nil {
+					return fmt.Errorf("the reference %q doesn't resolve", ref.Value)
+				}
+
+				inlining[ref.Value] = true
+				defer delete(inlining, ref.Value)
+				*node = *copyNode(target)
+				return inlineSubschemaRefs(node, schemas, inlining)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if err := inlineSubschemaRefs(child, schemas, inlining); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pointerChild returns the child of node for the JSON Pointer token, or nil
+// if there's none.
+func pointerChild(node *yaml.Node, token string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return mappingValue(node, token)
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(node.Content) {
+			return nil
+		}
+		return node.Content[i]
+	}
+	return nil
+}
+
+// unescapePointerToken returns the JSON Pointer token unescaped.
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// copyNode returns a deep copy of node.
+func copyNode(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// jsonSchemaReader returns the ReadFromURIFunc reading documents with read,
+// which wraps the JSON Schema documents amongst them in OpenAPI documents,
+// so that they can be referenced from the documents wrapped already.
+func jsonSchemaReader(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		if specKindOf(data) != specJSONSchema {
+			return data, nil
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, err
+		}
+		doc, err := newJSONSchemaDocument(locationPath(location), &node)
+		if err != nil {
+			return nil, err
+		}
+		wrapper, err := wrapJSONSchemas([]*jsonSchemaDocument{doc}, false)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(wrapper)
+	}
+}
+
+// locationPath returns the path, or the URL, of location.
+func locationPath(location *url.URL) string {
+	if location.Scheme != "" && location.Host != "" {
+		return location.String()
+	}
+	return filepath.FromSlash(location.Path)
+}
+
+// loadJSONSchemas loads the OpenAPI document wrapping the JSON Schema
+// documents docs, with loader, from which the documents of the external
+// references are read, at location.
+func loadJSONSchemas(loader *openapi3.Loader, docs []*jsonSchemaDocument, location *url.URL) (*openapi3.T, error) {
+	wrapper, err := wrapJSONSchemas(docs, true)
+	if err != nil {
+		return nil, err
+	}
+	return loadWrapper(loader, wrapper, location)
+}
+
+// loadWrapper loads the OpenAPI document wrapper, wrapping JSON Schema or
+// AsyncAPI documents, at location, with loader, from which the documents of
+// its external references are read, wrapping the JSON Schema documents.
+func loadWrapper(loader *openapi3.Loader, wrapper *yaml.Node, location *url.URL) (*openapi3.T, error) {
+	data, err := yaml.Marshal(wrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the wrapping OpenAPI document: %w", err)
+	}
+
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = openapi3.DefaultReadFromURI
+	}
+	loader.ReadFromURIFunc = jsonSchemaReader(read)
+	return loader.LoadFromDataWithPath(data, location)
+}
+
+// loadJSONSchemaDir loads the JSON Schema documents of the directory dir,
+// which are its `.json`, `.yaml` and `.yml` files which aren't OpenAPI,
+// Swagger or AsyncAPI documents, as a single OpenAPI document, in which
+// references between them are local.
+func loadJSONSchemaDir(loader *openapi3.Loader, dir string) (*openapi3.T, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = openapi3.DefaultReadFromURI
+	}
+
+	var docs []*jsonSchemaDocument
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		location := filepath.Join(dir, entry.Name())
+		data, err := read(loader, &url.URL{Path: filepath.ToSlash(location)})
+		if err != nil {
+			return nil, err
+		}
+		// Documents without `$schema` are taken for JSON Schemas too.
+		if kind := specKindOf(data); kind != specJSONSchema && kind != specUnknown {
+			continue
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, fmt.Errorf("failed to parse %#v: %w", location, err)
+		}
+		doc, err := newJSONSchemaDocument(location, &node)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("there are no JSON Schema documents in %#v", dir)
+	}
+
+	// The wrapping document is loaded as if it were in dir, to which the
+	// external references of its documents are relative.
+	return loadJSONSchemas(loader, docs, &url.URL{Path: filepath.ToSlash(filepath.Join(dir, "openapi.yaml"))})
+}
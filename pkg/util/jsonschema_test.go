@@ -0,0 +1,98 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonSchemaPet = `$schema: https://json-schema.org/draft-07/schema#
+title: Pet
+type: object
+properties:
+  name: {type: string}
+  tag: {$ref: "#/definitions/Tag"}
+  owner: {$ref: "owner.json"}
+  nickname: {$ref: "#/properties/name"}
+definitions:
+  Tag: {type: string}
+`
+
+const jsonSchemaOwner = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {"pets": {"type": "array", "items": {"$ref": "pet.yaml"}}}
+}`
+
+func writeJSONSchemas(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(jsonSchemaPet), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "owner.json"), []byte(jsonSchemaOwner), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.yaml"), []byte("openapi: 3.0.0\ninfo: {title: API, version: \"1\"}\npaths: {}\n"), 0o644))
+	return dir
+}
+
+func TestLoadJSONSchema(t *testing.T) {
+	dir := writeJSONSchemas(t)
+
+	swagger, err := LoadSwagger(filepath.Join(dir, "pet.yaml"))
+	require.NoError(t, err)
+	assert.True(t, IsJSONSchemaSpec(swagger))
+	assert.Equal(t, "3.1.0", swagger.OpenAPI)
+
+	// The root schema and its definitions are component schemas.
+	require.Len(t, swagger.Components.Schemas, 2)
+	pet := swagger.Components.Schemas["pet"].Value
+	require.NotNil(t, pet)
+	assert.Equal(t, "Pet", pet.Title)
+	assert.Equal(t, "#/components/schemas/Tag", pet.Properties["tag"].Ref)
+
+	// References to subschemas are inlined.
+	assert.Equal(t, "", pet.Properties["nickname"].Ref)
+	assert.True(t, pet.Properties["nickname"].Value.Type.Is("string"))
+
+	// Other documents are wrapped as they're referenced.
+	owner := pet.Properties["owner"]
+	assert.Equal(t, "owner.json#/components/schemas/owner", owner.Ref)
+	require.NotNil(t, owner.Value)
+	assert.Contains(t, owner.Value.Properties, "pets")
+}
+
+func TestLoadJSONSchemaDir(t *testing.T) {
+	dir := writeJSONSchemas(t)
+
+	swagger, err := LoadSwagger(dir)
+	require.NoError(t, err)
+	assert.True(t, IsJSONSchemaSpec(swagger))
+
+	// The documents of the directory reference each other locally, and its
+	// OpenAPI documents are skipped.
+	require.Len(t, swagger.Components.Schemas, 3)
+	assert.Equal(t, "#/components/schemas/owner", swagger.Components.Schemas["pet"].Value.Properties["owner"].Ref)
+	assert.Equal(t, "#/components/schemas/pet", swagger.Components.Schemas["owner"].Value.Properties["pets"].Value.Items.Ref)
+
+	_, err = LoadSwaggerWithOverlay(dir, LoadSwaggerWithOverlayOpts{Path: filepath.Join(dir, "overlay.yaml")})
+	assert.ErrorContains(t, err, "an Overlay can't be applied to the directory of JSON Schemas")
+}
+
+func TestLoadJSONSchemaWithOverlay(t *testing.T) {
+	dir := writeJSONSchemas(t)
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(`overlay: 1.0.0
+info: {title: Overlay, version: "1"}
+actions:
+  - target: $.definitions.Tag
+    update:
+      maxLength: 10
+`), 0o644))
+
+	// The Overlay targets the JSON Schema document.
+	swagger, err := LoadSwaggerWithOverlay(filepath.Join(dir, "pet.yaml"), LoadSwaggerWithOverlayOpts{Path: overlayPath, Strict: true})
+	require.NoError(t, err)
+	require.NotNil(t, swagger.Components.Schemas["Tag"].Value.MaxLength)
+	assert.Equal(t, uint64(10), *swagger.Components.Schemas["Tag"].Value.MaxLength)
+}
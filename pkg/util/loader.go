@@ -2,9 +2,14 @@ package util
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -12,13 +17,39 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// LoadSwagger loads the spec at filePath, which may be a URL. Swagger 2.0
+// specs are converted to OpenAPI 3.0, and JSON Schema documents, or the
+// directories of them, are wrapped in an OpenAPI 3.1 spec, whose component
+// schemas are their schemas.
 func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
+	return loadSwagger(newLoader(nil), filePath, nil)
+}
 
+// newLoader returns the loader of specs, which records the digests of the
+// documents it reads in digester, if it isn't nil.
+func newLoader(digester *documentDigester) *openapi3.Loader {
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
 	// Record each element's source location so route registration can be
 	// emitted in the order paths are declared in the spec (issue #1887).
 	loader.IncludeOrigin = true
+	if digester != nil {
+		loader.ReadFromURIFunc = digester.readFromURI
+	}
+	return loader
+}
+
+func loadSwagger(loader *openapi3.Loader, filePath string, warn func(Swagger2Warning)) (swagger *openapi3.T, err error) {
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		return loadJSONSchemaDir(loader, filePath)
+	}
+	node, kind, err := readConvertedSpec(loader, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if node != nil {
+		return convertSpec(loader, node, kind, filePath, warn)
+	}
 
 	u, err := url.Parse(filePath)
 	if err == nil && u.Scheme != "" && u.Host != "" {
@@ -38,10 +69,70 @@ func LoadSwaggerWithCircularReferenceCount(filePath string, _ int) (swagger *ope
 type LoadSwaggerWithOverlayOpts struct {
 	Path   string
 	Strict bool
+	// Warn is called with the constructs of a Swagger 2.0 spec which don't
+	// translate losslessly to OpenAPI 3.0, if it isn't nil. The Overlay is
+	// applied to Swagger 2.0 specs before they're converted.
+	Warn func(Swagger2Warning)
 }
 
 func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, err error) {
-	spec, err := LoadSwagger(filePath)
+	return loadSwaggerWithOverlay(nil, filePath, opts)
+}
+
+// LoadSwaggerWithOverlayAndDigest is LoadSwaggerWithOverlay, which also
+// returns the SHA-256 digest of the documents the spec was loaded from: the
+// spec, the documents of its external references, and the Overlay. The
+// digest changes whenever one of them does.
+func LoadSwaggerWithOverlayAndDigest(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, digest string, err error) {
+	digester := &documentDigester{}
+	swagger, err = loadSwaggerWithOverlay(digester, filePath, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if opts.Path != "" {
+		overlay, err := os.ReadFile(opts.Path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read Overlay from %#v: %w", opts.Path, err)
+		}
+		digester.add("overlay", overlay)
+	}
+	return swagger, digester.digest(), nil
+}
+
+// LoadSwaggerWithOverlayAndFiles is LoadSwaggerWithOverlay, which also
+// returns the local files the spec was loaded from: the spec, the documents
+// of its external references, and the Overlay. They're returned even when
+// the spec fails to load, as far as it was read.
+func LoadSwaggerWithOverlayAndFiles(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, files []string, err error) {
+	digester := &documentDigester{}
+	swagger, err = loadSwaggerWithOverlay(digester, filePath, opts)
+	if opts.Path != "" {
+		digester.addFile(opts.Path)
+	}
+	return swagger, digester.sortedFiles(), err
+}
+
+func loadSwaggerWithOverlay(digester *documentDigester, filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, err error) {
+	if opts.Path != "" {
+		if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+			return nil, fmt.Errorf("an Overlay can't be applied to the directory of JSON Schemas %#v", filePath)
+		}
+		// Overlays of Swagger 2.0 specs, and of JSON Schemas, target the
+		// document as it's written.
+		loader := newLoader(digester)
+		node, kind, err := readConvertedSpec(loader, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
+		}
+		if node != nil {
+			if err := applyOverlay(node, filePath, opts); err != nil {
+				return nil, err
+			}
+			return convertSpec(loader, node, kind, filePath, opts.Warn)
+		}
+	}
+
+	spec, err := loadSwagger(newLoader(digester), filePath, opts.Warn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
 	}
@@ -66,43 +157,110 @@ func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (s
 		return nil, fmt.Errorf("failed to parse spec from %#v: %w", filePath, err)
 	}
 
+	if err := applyOverlay(&node, filePath, opts); err != nil {
+		return nil, err
+	}
+
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize Overlay'd specification %#v: %v", opts.Path, err)
+	}
+
+	swagger, err = newLoader(digester).LoadFromDataWithPath(b, &url.URL{
+		Path: filepath.ToSlash(filePath),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize Overlay'd specification %#v: %v", opts.Path, err)
+	}
+
+	return swagger, nil
+}
+
+// applyOverlay applies the Overlay of opts to node, the spec at filePath.
+func applyOverlay(node *yaml.Node, filePath string, opts LoadSwaggerWithOverlayOpts) error {
 	overlay, err := loader.LoadOverlay(opts.Path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load Overlay from %#v: %v", opts.Path, err)
+		return fmt.Errorf("failed to load Overlay from %#v: %v", opts.Path, err)
 	}
 
 	err = overlay.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("the Overlay in %#v was not valid: %v", opts.Path, err)
+		return fmt.Errorf("the Overlay in %#v was not valid: %v", opts.Path, err)
 	}
 
 	if opts.Strict {
-		vs, err := overlay.ApplyToStrict(&node)
+		vs, err := overlay.ApplyToStrict(node)
 		if err != nil {
-			return nil, fmt.Errorf("failed to apply Overlay %#v to specification %#v: %v\nAdditionally, the following validation errors were found:\n- %s", opts.Path, filePath, err, strings.Join(vs, "\n- "))
+			return fmt.Errorf("failed to apply Overlay %#v to specification %#v: %v\nAdditionally, the following validation errors were found:\n- %s", opts.Path, filePath, err, strings.Join(vs, "\n- "))
 		}
 	} else {
-		err = overlay.ApplyTo(&node)
+		err = overlay.ApplyTo(node)
 		if err != nil {
-			return nil, fmt.Errorf("failed to apply Overlay %#v to specification %#v: %v", opts.Path, filePath, err)
+			return fmt.Errorf("failed to apply Overlay %#v to specification %#v: %v", opts.Path, filePath, err)
 		}
 	}
+	return nil
+}
 
-	b, err := yaml.Marshal(&node)
+// documentDigester records the digests of the documents which loaders read,
+// and the local files they're read from.
+type documentDigester struct {
+	digests map[string]bool
+	files   map[string]bool
+}
+
+// readDocument reads documents as openapi3.DefaultReadFromURI does, without
+// its cache, which would keep on returning documents as they were first read.
+var readDocument = openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile)
+
+// readFromURI reads the document at location, recording its digest.
+func (d *documentDigester) readFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	data, err := readDocument(loader, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to serialize Overlay'd specification %#v: %v", opts.Path, err)
+		return nil, err
+	}
+	d.add("document", data)
+	if location.Scheme == "" || location.Scheme == "file" {
+		d.addFile(filepath.FromSlash(location.Path))
 	}
+	return data, nil
+}
 
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
-	loader.IncludeOrigin = true
+// addFile records the local file at path.
+func (d *documentDigester) addFile(path string) {
+	if d.files == nil {
+		d.files = map[string]bool{}
+	}
+	d.files[filepath.Clean(path)] = true
+}
 
-	swagger, err = loader.LoadFromDataWithPath(b, &url.URL{
-		Path: filepath.ToSlash(filePath),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize Overlay'd specification %#v: %v", opts.Path, err)
+// sortedFiles returns the local files recorded so far.
+func (d *documentDigester) sortedFiles() []string {
+	files := make([]string, 0, len(d.files))
+	for file := range d.files {
+		files = append(files, file)
 	}
+	sort.Strings(files)
+	return files
+}
 
-	return swagger, nil
+// add records the digest of data, of the given kind of document.
+func (d *documentDigester) add(kind string, data []byte) {
+	if d.digests == nil {
+		d.digests = map[string]bool{}
+	}
+	sum := sha256.Sum256(data)
+	d.digests[kind+" "+hex.EncodeToString(sum[:])] = true
+}
+
+// digest returns the digest of the documents recorded so far, regardless of
+// the order they were read in.
+func (d *documentDigester) digest() string {
+	digests := make([]string, 0, len(d.digests))
+	for digest := range d.digests {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+	sum := sha256.Sum256([]byte(strings.Join(digests, "\n")))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,485 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	oasyaml "github.com/oasdiff/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// Swagger2Warning is a warning about a construct of a Swagger 2.0 spec which
+// doesn't translate losslessly to OpenAPI 3.0.
+type Swagger2Warning struct {
+	// File is the path, or the URL, of the spec.
+	File string
+	// Line and Column locate the construct in File, starting at 1.
+	Line, Column int
+	// Message describes how the construct is converted.
+	Message string
+}
+
+func (w Swagger2Warning) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", w.File, w.Line, w.Column, w.Message)
+}
+
+// specLocation returns the location of the spec at filePath, which may be a
+// URL.
+func specLocation(filePath string) *url.URL {
+	u, err := url.Parse(filePath)
+	if err == nil && u.Scheme != "" && u.Host != "" {
+		return u
+	}
+	return &url.URL{Path: filepath.ToSlash(filePath)}
+}
+
+// specKind is the kind of the document of a spec.
+type specKind int
+
+const (
+	// specOpenAPI is an OpenAPI 3 document, or a document of unknown kind,
+	// which is left to the loader.
+	specOpenAPI specKind = iota
+	// specSwagger2 is a Swagger 2.0 document, converted to OpenAPI 3.0.
+	specSwagger2
+	// specJSONSchema is a JSON Schema document, declaring its `$schema`,
+	// wrapped in an OpenAPI 3.1 document.
+	specJSONSchema
+	// specAsyncAPI is an AsyncAPI document, wrapped in an OpenAPI 3.1
+	// document.
+	specAsyncAPI
+	// specUnknown is a document which declares none of `openapi`,
+	// `swagger`, `asyncapi` and `$schema`.
+	specUnknown
+)
+
+// specKindOf returns the kind of the document data.
+func specKindOf(data []byte) specKind {
+	var header struct {
+		OpenAPI  yaml.Node `yaml:"openapi"`
+		Swagger  yaml.Node `yaml:"swagger"`
+		AsyncAPI yaml.Node `yaml:"asyncapi"`
+		Schema   yaml.Node `yaml:"$schema"`
+	}
+	// Documents which can't be decoded are left to the loader to report.
+	if yaml.Unmarshal(data, &header) != nil {
+		return specOpenAPI
+	}
+	switch {
+	case header.OpenAPI.Value != "":
+		return specOpenAPI
+	case header.Swagger.Value == "2.0":
+		return specSwagger2
+	case header.Swagger.Value != "":
+		return specOpenAPI
+	case header.AsyncAPI.Value != "":
+		return specAsyncAPI
+	case strings.Contains(header.Schema.Value, "json-schema.org"):
+		return specJSONSchema
+	}
+	return specUnknown
+}
+
+// readConvertedSpec returns the document of the spec at filePath, and its
+// kind, if it's a Swagger 2.0, JSON Schema or AsyncAPI document, which are
+// converted rather than loaded as they are, or nil if it isn't.
+func readConvertedSpec(loader *openapi3.Loader, filePath string) (*yaml.Node, specKind, error) {
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = openapi3.DefaultReadFromURI
+	}
+	data, err := read(loader, specLocation(filePath))
+	if err != nil {
+		return nil, specOpenAPI, err
+	}
+
+	kind := specKindOf(data)
+	if kind != specSwagger2 && kind != specJSONSchema && kind != specAsyncAPI {
+		return nil, kind, nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, kind, err
+	}
+	return &node, kind, nil
+}
+
+// convertSpec converts the document node of the spec at filePath, of the
+// given kind, as returned by readConvertedSpec, to OpenAPI 3.
+func convertSpec(loader *openapi3.Loader, node *yaml.Node, kind specKind, filePath string, warn func(Swagger2Warning)) (*openapi3.T, error) {
+	switch kind {
+	case specJSONSchema:
+		doc, err := newJSONSchemaDocument(filePath, node)
+		if err != nil {
+			return nil, err
+		}
+		return loadJSONSchemas(loader, []*jsonSchemaDocument{doc}, specLocation(filePath))
+	case specAsyncAPI:
+		return loadAsyncAPI(loader, node, filePath)
+	}
+	return convertSwagger2(loader, node, filePath, warn)
+}
+
+// convertSwagger2 converts the Swagger 2.0 document at filePath to OpenAPI
+// 3.0, resolving its external references with loader, and calling warn, if
+// it isn't nil, with the constructs which don't translate losslessly.
+func convertSwagger2(loader *openapi3.Loader, node *yaml.Node, filePath string, warn func(Swagger2Warning)) (*openapi3.T, error) {
+	if warn != nil {
+		for _, warning := range swagger2Warnings(node, filePath) {
+			warn(warning)
+		}
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Swagger 2.0 spec %#v: %w", filePath, err)
+	}
+	var doc2 openapi2.T
+	if _, err := oasyaml.Unmarshal(data, &doc2, oasyaml.DecodeOpts{DisableTimestamps: true}); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger 2.0 spec %#v: %w", filePath, err)
+	}
+	swagger, err := openapi2conv.ToV3WithLoader(&doc2, loader, specLocation(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger 2.0 spec %#v to OpenAPI 3.0: %w", filePath, err)
+	}
+
+	convertCollectionFormats(&doc2, swagger)
+	convertExternalRefs(swagger)
+	if loader.IncludeOrigin {
+		setPathOrigins(node, swagger, filePath)
+	}
+	return swagger, nil
+}
+
+// parameterStyle returns the OpenAPI 3.0 style, and explode, of an array
+// parameter in in, whose Swagger 2.0 collectionFormat is format, and whether
+// there's one.
+func parameterStyle(in, format string) (style string, explode bool, ok bool) {
+	switch in {
+	case "query":
+		switch format {
+		case "", "csv":
+			return "form", false, true
+		case "multi":
+			return "form", true, true
+		case "ssv":
+			return "spaceDelimited", false, true
+		case "pipes":
+			return "pipeDelimited", false, true
+		}
+	case "path", "header":
+		if format == "" || format == "csv" {
+			return "simple", false, true
+		}
+	}
+	return "", false, false
+}
+
+// convertCollectionFormats sets the style of the array parameters of
+// swagger, converted from doc2, from their collectionFormat, which the
+// conversion leaves out: the Swagger 2.0 default, csv, isn't the OpenAPI 3.0
+// default of query parameters.
+func convertCollectionFormats(doc2 *openapi2.T, swagger *openapi3.T) {
+	if swagger.Components != nil {
+		for name, param := range doc2.Parameters {
+			if ref, ok := swagger.Components.Parameters[name]; ok && ref.Ref == "" {
+				setParameterStyle(param, ref.Value)
+			}
+		}
+	}
+	if swagger.Paths == nil {
+		return
+	}
+	for path, pathItem2 := range doc2.Paths {
+		pathItem := swagger.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		setParameterStyles(pathItem2.Parameters, pathItem.Parameters)
+		for method, op2 := range pathItem2.Operations() {
+			if op := pathItem.GetOperation(method); op != nil {
+				setParameterStyles(op2.Parameters, op.Parameters)
+			}
+		}
+	}
+}
+
+// setParameterStyles sets the style of params from their counterpart in
+// params2.
+func setParameterStyles(params2 openapi2.Parameters, params openapi3.Parameters) {
+	for _, ref := range params {
+		if ref.Ref != "" || ref.Value == nil {
+			continue
+		}
+		for _, param2 := range params2 {
+			if param2.Ref == "" && param2.In == ref.Value.In && param2.Name == ref.Value.Name {
+				setParameterStyle(param2, ref.Value)
+				break
+			}
+		}
+	}
+}
+
+// setParameterStyle sets the style of param from the collectionFormat of
+// param2, unless it's the default style.
+func setParameterStyle(param2 *openapi2.Parameter, param *openapi3.Parameter) {
+	if param == nil || param2.Type == nil || !param2.Type.Is("array") {
+		return
+	}
+	style, explode, ok := parameterStyle(param2.In, param2.CollectionFormat)
+	if !ok {
+		return
+	}
+	// Query parameters default to the form style, exploded, and the others
+	// to the simple style, which csv already is.
+	if param2.In == "query" && !(style == "form" && explode) {
+		param.Style = style
+		param.Explode = &explode
+	}
+}
+
+// convertExternalRefs converts the Swagger 2.0 JSON pointers of the external
+// references of swagger, which the conversion leaves as they are, such as
+// `defs.yaml#/definitions/Pet` to `defs.yaml#/components/schemas/Pet`, which
+// is how code generated from their document names their types. They're
+// resolved already.
+func convertExternalRefs(swagger *openapi3.T) {
+	convertRef := func(ref string) string {
+		document, pointer, ok := strings.Cut(ref, "#")
+		if !ok || document == "" {
+			return ref
+		}
+		return document + openapi2conv.ToV3Ref("#"+pointer)
+	}
+
+	visited := map[*openapi3.Schema]bool{}
+	var convertSchema func(schema *openapi3.SchemaRef)
+	convertSchema = func(schema *openapi3.SchemaRef) {
+		if schema == nil {
+			return
+		}
+		schema.Ref = convertRef(schema.Ref)
+		if schema.Value == nil || visited[schema.Value] {
+			return
+		}
+		visited[schema.Value] = true
+		for _, property := range schema.Value.Properties {
+			convertSchema(property)
+		}
+		for _, schemas := range []openapi3.SchemaRefs{schema.Value.AllOf, schema.Value.AnyOf, schema.Value.OneOf} {
+			for _, s := range schemas {
+				convertSchema(s)
+			}
+		}
+		convertSchema(schema.Value.Items)
+		convertSchema(schema.Value.Not)
+		convertSchema(schema.Value.AdditionalProperties.Schema)
+	}
+	convertContent := func(content openapi3.Content) {
+		for _, mediaType := range content {
+			convertSchema(mediaType.Schema)
+		}
+	}
+	convertParameters := func(params openapi3.Parameters) {
+		for _, param := range params {
+			param.Ref = convertRef(param.Ref)
+			if param.Value != nil {
+				convertSchema(param.Value.Schema)
+			}
+		}
+	}
+	convertResponse := func(response *openapi3.ResponseRef) {
+		response.Ref = convertRef(response.Ref)
+		if response.Value == nil {
+			return
+		}
+		convertContent(response.Value.Content)
+		for _, header := range response.Value.Headers {
+			if header.Value != nil {
+				convertSchema(header.Value.Schema)
+			}
+		}
+	}
+	convertRequestBody := func(body *openapi3.RequestBodyRef) {
+		if body != nil && body.Value != nil {
+			convertContent(body.Value.Content)
+		}
+	}
+
+	if components := swagger.Components; components != nil {
+		for _, schema := range components.Schemas {
+			convertSchema(schema)
+		}
+		for _, param := range components.Parameters {
+			convertParameters(openapi3.Parameters{param})
+		}
+		for _, body := range components.RequestBodies {
+			convertRequestBody(body)
+		}
+		for _, response := range components.Responses {
+			convertResponse(response)
+		}
+	}
+	if swagger.Paths == nil {
+		return
+	}
+	for _, pathItem := range swagger.Paths.Map() {
+		convertParameters(pathItem.Parameters)
+		for _, op := range pathItem.Operations() {
+			convertParameters(op.Parameters)
+			convertRequestBody(op.RequestBody)
+			if op.Responses != nil {
+				for _, response := range op.Responses.Map() {
+					convertResponse(response)
+				}
+			}
+		}
+	}
+}
+
+// setPathOrigins records where the paths of swagger are declared in the
+// Swagger 2.0 document node, so that they're generated in that order.
+func setPathOrigins(node *yaml.Node, swagger *openapi3.T, filePath string) {
+	paths := mappingValue(documentRoot(node), "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode || swagger.Paths == nil {
+		return
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		key := paths.Content[i]
+		pathItem := swagger.Paths.Value(key.Value)
+		if pathItem == nil {
+			continue
+		}
+		pathItem.Origin = &openapi3.Origin{
+			Key: &openapi3.Location{File: filePath, Line: key.Line, Column: key.Column, Name: key.Value},
+		}
+	}
+}
+
+// swagger2Warnings returns the warnings about the constructs of the Swagger
+// 2.0 document node which don't translate losslessly to OpenAPI 3.0.
+func swagger2Warnings(node *yaml.Node, filePath string) []Swagger2Warning {
+	var warnings []Swagger2Warning
+	warnf := func(at *yaml.Node, format string, args ...any) {
+		warnings = append(warnings, Swagger2Warning{
+			File:    filePath,
+			Line:    at.Line,
+			Column:  at.Column,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	checkParameter := func(param *yaml.Node) {
+		if ref := mappingValue(param, "$ref"); ref != nil {
+			if !strings.HasPrefix(ref.Value, "#") {
+				warnf(ref, "the parameter %q of another document is loaded as an OpenAPI 3.0 parameter, without being converted", ref.Value)
+			}
+			return
+		}
+		in, name := scalarValue(param, "in"), scalarValue(param, "name")
+		typ := mappingValue(param, "type")
+		format := mappingValue(param, "collectionFormat")
+		if in == "formData" {
+			if typ != nil && typ.Value == "file" {
+				warnf(typ, "the file parameter %q is converted to a binary string property of the request body", name)
+			}
+			if format != nil {
+				warnf(format, "the collectionFormat %s of the formData parameter %q isn't carried over to the request body", format.Value, name)
+			}
+			return
+		}
+		if format != nil {
+			if _, _, ok := parameterStyle(in, format.Value); !ok {
+				warnf(format, "the collectionFormat %s of the %s parameter %q has no OpenAPI 3.0 equivalent, so the parameter gets the default style", format.Value, in, name)
+			}
+		}
+		if items := mappingValue(param, "items"); items != nil {
+			if format := mappingValue(items, "collectionFormat"); format != nil {
+				warnf(format, "the collectionFormat %s of the items of the %s parameter %q has no OpenAPI 3.0 equivalent, so they're arrays of the default style", format.Value, in, name)
+			}
+		}
+	}
+	checkParameters := func(params *yaml.Node) {
+		if params == nil {
+			return
+		}
+		for _, param := range params.Content {
+			checkParameter(param)
+		}
+	}
+	checkResponses := func(responses *yaml.Node) {
+		if responses == nil || responses.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 1; i < len(responses.Content); i += 2 {
+			if ref := mappingValue(responses.Content[i], "$ref"); ref != nil && !strings.HasPrefix(ref.Value, "#") {
+				warnf(ref, "the response %q of another document is loaded as an OpenAPI 3.0 response, without being converted", ref.Value)
+			}
+		}
+	}
+
+	root := documentRoot(node)
+	if params := mappingValue(root, "parameters"); params != nil && params.Kind == yaml.MappingNode {
+		for i := 1; i < len(params.Content); i += 2 {
+			checkParameter(params.Content[i])
+		}
+	}
+	checkResponses(mappingValue(root, "responses"))
+	if paths := mappingValue(root, "paths"); paths != nil && paths.Kind == yaml.MappingNode {
+		for i := 1; i < len(paths.Content); i += 2 {
+			pathItem := paths.Content[i]
+			if pathItem.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(pathItem.Content); j += 2 {
+				key, value := pathItem.Content[j].Value, pathItem.Content[j+1]
+				if key == "parameters" {
+					checkParameters(value)
+					continue
+				}
+				if strings.HasPrefix(key, "x-") || key == "$ref" {
+					continue
+				}
+				checkParameters(mappingValue(value, "parameters"))
+				checkResponses(mappingValue(value, "responses"))
+			}
+		}
+	}
+	return warnings
+}
+
+// documentRoot returns the root node of the document node.
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// mappingValue returns the value of key in the mapping node, or nil if it
+// isn't a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the value of key in the mapping node, or "" if it has
+// no such scalar.
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const swagger2Spec = `swagger: "2.0"
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: tags, in: query, type: array, items: {type: string}}
+        - {name: ids, in: query, type: array, collectionFormat: multi, items: {type: integer}}
+        - {name: names, in: query, type: array, collectionFormat: tsv, items: {type: string}}
+        - $ref: "#/parameters/Limit"
+      responses:
+        200:
+          description: ok
+          schema:
+            $ref: "defs.yaml#/definitions/Pet"
+  /pets/{id}/photo:
+    post:
+      operationId: uploadPhoto
+      consumes: [multipart/form-data]
+      parameters:
+        - {name: id, in: path, required: true, type: string}
+        - {name: photo, in: formData, type: file, required: true}
+      responses:
+        204: {description: ok}
+parameters:
+  Limit: {name: limit, in: query, type: array, collectionFormat: pipes, items: {type: integer}}
+`
+
+const swagger2Defs = `definitions:
+  Pet:
+    type: object
+    properties:
+      name: {type: string}
+`
+
+func writeSwagger2Spec(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.yaml"), []byte(swagger2Spec), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "defs.yaml"), []byte(swagger2Defs), 0o644))
+	return filepath.Join(dir, "api.yaml")
+}
+
+func TestLoadSwagger2(t *testing.T) {
+	specPath := writeSwagger2Spec(t)
+
+	var warnings []Swagger2Warning
+	swagger, err := LoadSwaggerWithOverlay(specPath, LoadSwaggerWithOverlayOpts{
+		Warn: func(warning Swagger2Warning) {
+			warnings = append(warnings, warning)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.3", swagger.OpenAPI)
+
+	// The collectionFormat of query parameters becomes their style.
+	params := swagger.Paths.Value("/pets").Get.Parameters
+	require.Len(t, params, 4)
+	assert.Equal(t, "form", params.GetByInAndName("query", "tags").Style)
+	assert.False(t, *params.GetByInAndName("query", "tags").Explode)
+	assert.Equal(t, "", params.GetByInAndName("query", "ids").Style)
+	assert.Equal(t, "pipeDelimited", swagger.Components.Parameters["Limit"].Value.Style)
+
+	// External references are resolved, with OpenAPI 3.0 pointers.
+	schema := swagger.Paths.Value("/pets").Get.Responses.Status(200).Value.Content["application/json"].Schema
+	assert.Equal(t, "defs.yaml#/components/schemas/Pet", schema.Ref)
+	require.NotNil(t, schema.Value)
+	assert.Contains(t, schema.Value.Properties, "name")
+
+	// The constructs which don't translate losslessly are reported where
+	// they are.
+	require.Len(t, warnings, 2)
+	assert.Equal(t, Swagger2Warning{
+		File:    specPath,
+		Line:    10,
+		Column:  67,
+		Message: `the collectionFormat tsv of the query parameter "names" has no OpenAPI 3.0 equivalent, so the parameter gets the default style`,
+	}, warnings[0])
+	assert.Equal(t, 23, warnings[1].Line)
+	assert.Contains(t, warnings[1].Message, `the file parameter "photo"`)
+}
+
+func TestLoadSwagger2WithOverlay(t *testing.T) {
+	specPath := writeSwagger2Spec(t)
+	overlayPath := filepath.Join(filepath.Dir(specPath), "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(`overlay: 1.0.0
+info: {title: Overlay, version: "1"}
+actions:
+  - target: $.parameters.Limit
+    update:
+      collectionFormat: ssv
+`), 0o644))
+
+	// The Overlay targets the Swagger 2.0 spec.
+	swagger, err := LoadSwaggerWithOverlay(specPath, LoadSwaggerWithOverlayOpts{Path: overlayPath, Strict: true})
+	require.NoError(t, err)
+	assert.Equal(t, "spaceDelimited", swagger.Components.Parameters["Limit"].Value.Style)
+}